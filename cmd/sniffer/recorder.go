@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/utils"
+)
+
+// Single recorded message.
+type record struct {
+	Time    int64           `json:"t"`
+	Channel string          `json:"c"`
+	Body    json.RawMessage `json:"b"`
+}
+
+// Records sniffed messages into a file.
+// Each line contains a single JSON-encoded record.
+type recorder struct {
+	sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+// Creates a new recorder.
+func newRecorder(fileName string) (*recorder, error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recorder{
+		file:    f,
+		encoder: json.NewEncoder(f),
+	}, nil
+}
+
+// Write records a new message.
+func (r *recorder) Write(channel string, body []byte) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if !json.Valid(body) {
+		return errors.New("message is not a valid json")
+	}
+
+	return r.encoder.Encode(&record{
+		Time:    time.Now().UnixNano(),
+		Channel: channel,
+		Body:    body,
+	})
+}
+
+// Close closes underlying file.
+func (r *recorder) Close() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.file.Close() // nolint: gosec, errcheck
+}
+
+// Replays recorded messages preserving original intervals.
+// Send time is re-stamped, so receivers won't discard messages as too old.
+func replay(busProvider providers.IBusProvider, logger *log.Logger, fileName string, speed float64) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close() // nolint: errcheck
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	var prev int64
+	cnt := 0
+	for scanner.Scan() {
+		rec := &record{}
+		err = json.Unmarshal(scanner.Bytes(), rec)
+		if err != nil {
+			return errors.Wrap(err, "corrupted record")
+		}
+
+		if 0 != prev {
+			wait(time.Duration(rec.Time-prev), speed)
+		}
+
+		prev = rec.Time
+		body, err := restamp(rec.Body)
+		if err != nil {
+			logger.Printf("Skipping corrupted message: %s", string(rec.Body))
+			continue
+		}
+
+		busProvider.PublishStr(rec.Channel, body)
+		cnt++
+		logger.Printf("[%s] replayed %s", rec.Channel, string(body))
+	}
+
+	logger.Printf("Replayed %d messages", cnt)
+	return scanner.Err()
+}
+
+// Updates message send time.
+func restamp(body json.RawMessage) (json.RawMessage, error) {
+	data := make(map[string]json.RawMessage)
+	err := json.Unmarshal(body, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := data["st"]; ok {
+		data["st"], _ = json.Marshal(utils.TimeNow()) // nolint: gosec
+	}
+
+	return json.Marshal(data)
+}
@@ -0,0 +1,310 @@
+// Package main contains service bus sniffer utility.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/jessevdk/go-flags"
+	"go-home.io/x/server/plugins/bus"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/settings"
+	busSystem "go-home.io/x/server/systems/bus"
+	"go-home.io/x/server/utils"
+)
+
+// Options defines arguments allowed by the sniffer.
+type options struct {
+	PluginsFolder string `short:"p" long:"plugins" description:"Plugins location."`
+	PluginsProxy  string `short:"x" long:"pluginsProxy" description:"Plugins download proxy"`
+
+	Config map[string]string `short:"c" long:"config" description:"Config files provider. Defaults to local FS."`
+	Secret map[string]string `short:"s" long:"secret" description:"Secrets provider. Defaults to local FS."`
+
+	Workers []string `short:"w" long:"worker" description:"Worker name filter. Supports globs."`
+	Devices []string `short:"d" long:"device" description:"Device ID filter. Supports globs."`
+	APIs    []string `short:"a" long:"api" description:"Extended API names to sniff."`
+	Raw     bool     `short:"r" long:"raw" description:"Print raw message body."`
+
+	Record string  `long:"record" description:"File to record all received messages."`
+	Replay string  `long:"replay" description:"File with recorded messages to replay."`
+	Speed  float64 `long:"speed" default:"1" description:"Replay speed multiplier. 0 replays without delays."`
+}
+
+// Service bus sniffer.
+type sniffer struct {
+	sync.Mutex
+	logger   *log.Logger
+	bus      providers.IBusProvider
+	queue    chan *sniffedMessage
+	workers  []glob.Glob
+	devices  []glob.Glob
+	raw      bool
+	recorder *recorder
+
+	knownWorkers map[string]bool
+}
+
+// Single received message.
+type sniffedMessage struct {
+	channel string
+	body    []byte
+}
+
+// Sniffs service bus traffic or replays recorded one.
+//noinspection GoUnhandledErrorResult
+func main() {
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	opts := &options{}
+	_, err := flags.Parse(opts)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	busProvider, _ := settings.LoadServiceBus(&settings.StartUpOptions{
+		PluginsFolder: opts.PluginsFolder,
+		PluginsProxy:  opts.PluginsProxy,
+		Config:        opts.Config,
+		Secret:        opts.Secret,
+	}, fmt.Sprintf("sniffer-%s", utils.GetRandomName()))
+
+	if "" != opts.Replay {
+		err = replay(busProvider, logger, opts.Replay, opts.Speed)
+		if err != nil {
+			logger.Fatalf("Failed to replay %s: %s", opts.Replay, err.Error())
+		}
+
+		return
+	}
+
+	s := &sniffer{
+		logger:       logger,
+		bus:          busProvider,
+		queue:        make(chan *sniffedMessage, 100),
+		workers:      compileFilters(opts.Workers, logger),
+		devices:      compileFilters(opts.Devices, logger),
+		raw:          opts.Raw,
+		knownWorkers: make(map[string]bool),
+	}
+
+	if "" != opts.Record {
+		s.recorder, err = newRecorder(opts.Record)
+		if err != nil {
+			logger.Fatalf("Failed to open record file %s: %s", opts.Record, err.Error())
+		}
+
+		defer s.recorder.Close()
+	}
+
+	s.subscribe(bus.ChDiscovery.String())
+	s.subscribe(bus.ChDeviceUpdates.String())
+	for _, v := range opts.APIs {
+		s.subscribe(fmt.Sprintf(bus.ChExtendedAPIFormat, utils.NormalizeDeviceName(v), "Srv"))
+		s.subscribe(fmt.Sprintf(bus.ChExtendedAPIFormat, utils.NormalizeDeviceName(v), "Wkr"))
+	}
+
+	go s.cycle()
+
+	logger.Print("Started service bus sniffer")
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+}
+
+// Compiles provided globs.
+func compileFilters(raw []string, logger *log.Logger) []glob.Glob {
+	res := make([]glob.Glob, 0)
+	for _, v := range raw {
+		g, err := glob.Compile(v)
+		if err != nil {
+			logger.Fatalf("Failed to compile filter %s: %s", v, err.Error())
+		}
+
+		res = append(res, g)
+	}
+
+	return res
+}
+
+// Subscribes to the channel and redirects all messages into the processing queue.
+func (s *sniffer) subscribe(channel string) {
+	in := make(chan bus.RawMessage, 50)
+	err := s.bus.SubscribeStr(channel, in)
+	if err != nil {
+		s.logger.Printf("Failed to subscribe to %s: %s", channel, err.Error())
+		return
+	}
+
+	go func() {
+		for msg := range in {
+			s.queue <- &sniffedMessage{channel: channel, body: msg.Body}
+		}
+	}()
+}
+
+// Subscribes to a worker channel, if it wasn't done before.
+func (s *sniffer) subscribeToWorker(name string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.knownWorkers[name] || !matches(s.workers, name) {
+		return
+	}
+
+	s.knownWorkers[name] = true
+	s.subscribe(fmt.Sprintf(bus.ChWorkerFormat, name))
+}
+
+// Main processing cycle.
+func (s *sniffer) cycle() {
+	for msg := range s.queue {
+		if nil != s.recorder {
+			err := s.recorder.Write(msg.channel, msg.body)
+			if err != nil {
+				s.logger.Printf("Failed to record message: %s", err.Error())
+			}
+		}
+
+		text, worker, device := s.describe(msg)
+		if !matches(s.workers, worker) || !matches(s.devices, device) {
+			continue
+		}
+
+		if s.raw {
+			text = fmt.Sprintf("%s %s", text, string(msg.body))
+		}
+
+		s.logger.Printf("[%s] %s", msg.channel, text)
+	}
+}
+
+// Decodes message into human readable form.
+// Returns description, worker and device IDs.
+// nolint: gocyclo
+func (s *sniffer) describe(msg *sniffedMessage) (string, string, string) {
+	worker := ""
+	if strings.HasPrefix(msg.channel, fmt.Sprintf(bus.ChWorkerFormat, "")) {
+		worker = strings.TrimPrefix(msg.channel, fmt.Sprintf(bus.ChWorkerFormat, ""))
+	}
+
+	if strings.HasPrefix(msg.channel, fmt.Sprintf(bus.ChExtendedAPIFormat, "", "")) {
+		return fmt.Sprintf("api message: %s", string(msg.body)), worker, ""
+	}
+
+	base := &busSystem.MessageWithType{}
+	err := json.Unmarshal(msg.body, base)
+	if err != nil {
+		return fmt.Sprintf("corrupted message: %s", string(msg.body)), worker, ""
+	}
+
+	age := utils.TimeNow() - base.SendTime
+	switch base.Type {
+	case bus.MsgPing:
+		m := &busSystem.DiscoveryMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		s.subscribeToWorker(m.NodeID)
+		return fmt.Sprintf("%s from %s, age %ds: first start %t, max devices %d, properties %v",
+			base.Type, m.NodeID, age, m.IsFirstStart, m.MaxDevices, m.Properties), m.NodeID, ""
+	case bus.MsgDeviceAssignment:
+		m := &busSystem.DeviceAssignmentMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		names := make([]string, 0)
+		for _, v := range m.Devices {
+			names = append(names, fmt.Sprintf("%s (%s)", v.Name, v.Plugin))
+		}
+
+		return fmt.Sprintf("%s to %s, age %ds: uom %s, devices [%s]",
+			base.Type, worker, age, m.UOM, strings.Join(names, ", ")), worker, ""
+	case bus.MsgDeviceUpdate:
+		m := &busSystem.DeviceUpdateMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s from %s, age %ds: %s %s, state %v, commands %v",
+			base.Type, m.WorkerID, age, m.DeviceType, m.DeviceID, m.State, m.Commands), m.WorkerID, m.DeviceID
+	case bus.MsgDeviceCommand:
+		m := &busSystem.DeviceCommandMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s to %s, age %ds: %s %s, payload %v",
+			base.Type, worker, age, m.DeviceID, m.Command, m.Payload), worker, m.DeviceID
+	case bus.MsgEntityLoadStatus:
+		m := &busSystem.EntityLoadStatusMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s from %s, age %ds: %s loaded %t",
+			base.Type, m.NodeID, age, m.Name, m.IsSuccess), m.NodeID, ""
+	case bus.MsgDeviceRemoved:
+		m := &busSystem.DeviceRemovedMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s from %s, age %ds: %s",
+			base.Type, m.WorkerID, age, m.DeviceID), m.WorkerID, m.DeviceID
+	case bus.MsgDeviceEvent:
+		m := &busSystem.DeviceEventMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s from %s, age %ds: %s %s, data %v",
+			base.Type, m.WorkerID, age, m.DeviceID, m.Event, m.Data), m.WorkerID, m.DeviceID
+	case bus.MsgDeviceResync:
+		m := &busSystem.DeviceResyncMessage{}
+		if err = json.Unmarshal(msg.body, m); err != nil {
+			break
+		}
+
+		return fmt.Sprintf("%s to %s, age %ds: %s",
+			base.Type, worker, age, m.DeviceID), worker, m.DeviceID
+	default:
+		return fmt.Sprintf("unknown message %s: %s", base.Type, string(msg.body)), worker, ""
+	}
+
+	return fmt.Sprintf("corrupted %s message: %s", base.Type, string(msg.body)), worker, ""
+}
+
+// Checks whether value matches any filter.
+// Empty value is not filtered out, since message doesn't relate to the filter.
+func matches(filters []glob.Glob, value string) bool {
+	if 0 == len(filters) || "" == value {
+		return true
+	}
+
+	for _, v := range filters {
+		if v.Match(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Sleeps for the recorded time difference, adjusted by speed.
+func wait(diff time.Duration, speed float64) {
+	if speed <= 0 || diff <= 0 {
+		return
+	}
+
+	time.Sleep(time.Duration(float64(diff) / speed))
+}
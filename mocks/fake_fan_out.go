@@ -11,8 +11,12 @@ type fakeFanOut struct {
 	inDeviceUpdates  chan *common.MsgDeviceUpdate
 	outDeviceUpdates map[int64]chan *common.MsgDeviceUpdate
 
+	inDeviceEvents chan *common.MsgDeviceEvent
+
 	inTriggerUpdates  chan string
 	outTriggerUpdates map[int64]chan string
+
+	inMetadataUpdates chan string
 }
 
 func (f *fakeFanOut) SubscribeDeviceUpdates() (int64, chan *common.MsgDeviceUpdate) {
@@ -26,6 +30,17 @@ func (f *fakeFanOut) ChannelInDeviceUpdates() chan *common.MsgDeviceUpdate {
 	return f.inDeviceUpdates
 }
 
+func (f *fakeFanOut) SubscribeDeviceEvents() (int64, chan *common.MsgDeviceEvent) {
+	return 1, f.inDeviceEvents
+}
+
+func (f *fakeFanOut) UnSubscribeDeviceEvents(int64) {
+}
+
+func (f *fakeFanOut) ChannelInDeviceEvents() chan *common.MsgDeviceEvent {
+	return f.inDeviceEvents
+}
+
 func (f *fakeFanOut) SubscribeTriggerUpdates() (int64, chan string) {
 	return 1, f.inTriggerUpdates
 }
@@ -37,6 +52,17 @@ func (f *fakeFanOut) ChannelInTriggerUpdates() chan string {
 	return f.inTriggerUpdates
 }
 
+func (f *fakeFanOut) SubscribeMetadataUpdates() (int64, chan string) {
+	return 1, f.inMetadataUpdates
+}
+
+func (f *fakeFanOut) UnSubscribeMetadataUpdates(int64) {
+}
+
+func (f *fakeFanOut) ChannelInMetadataUpdates() chan string {
+	return f.inMetadataUpdates
+}
+
 // FakeNewFanOut creates a new fake fan out provider.
 func FakeNewFanOut() providers.IInternalFanOutProvider {
 	return &fakeFanOut{
@@ -44,5 +70,7 @@ func FakeNewFanOut() providers.IInternalFanOutProvider {
 		outTriggerUpdates: make(map[int64]chan string),
 		inDeviceUpdates:   make(chan *common.MsgDeviceUpdate, 10),
 		outDeviceUpdates:  make(map[int64]chan *common.MsgDeviceUpdate),
+		inDeviceEvents:    make(chan *common.MsgDeviceEvent, 10),
+		inMetadataUpdates: make(chan string, 10),
 	}
 }
@@ -0,0 +1,135 @@
+//+build !release
+
+package mocks
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"go-home.io/x/server/providers"
+)
+
+type fakeMetadata struct {
+	sync.Mutex
+	devices map[string]*providers.DeviceMetadata
+}
+
+func (f *fakeMetadata) Get(ID string) *providers.DeviceMetadata {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.devices[ID]
+}
+
+func (f *fakeMetadata) All() map[string]*providers.DeviceMetadata {
+	f.Lock()
+	defer f.Unlock()
+
+	all := make(map[string]*providers.DeviceMetadata, len(f.devices))
+	for k, v := range f.devices {
+		all[k] = v
+	}
+
+	return all
+}
+
+func (f *fakeMetadata) Set(ID string, m *providers.DeviceMetadata) error {
+	f.Lock()
+	defer f.Unlock()
+
+	if nil == m || "" == m.Name && "" == m.Icon && 0 == len(m.Aliases) &&
+		0 == len(m.Tags) && !m.Hidden {
+		delete(f.devices, ID)
+		return nil
+	}
+
+	if existing, ok := f.devices[ID]; ok {
+		m.FirstSeen = existing.FirstSeen
+		m.PreviousIDs = existing.PreviousIDs
+	}
+
+	f.devices[ID] = m
+	return nil
+}
+
+func (f *fakeMetadata) Delete(ID string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	delete(f.devices, ID)
+	return nil
+}
+
+func (f *fakeMetadata) Match(exp glob.Glob, ID string) bool {
+	if exp.Match(ID) {
+		return true
+	}
+
+	m := f.Get(ID)
+	if nil == m {
+		return false
+	}
+
+	for _, v := range m.Aliases {
+		if exp.Match(v) {
+			return true
+		}
+	}
+
+	for _, v := range m.Tags {
+		if exp.Match("tag:" + v) {
+			return true
+		}
+	}
+
+	for _, v := range m.PreviousIDs {
+		if nil != f.Get(v) {
+			continue
+		}
+
+		if exp.Match(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *fakeMetadata) Register(ID string) bool {
+	f.Lock()
+	defer f.Unlock()
+
+	m, ok := f.devices[ID]
+	if ok && m.FirstSeen > 0 {
+		return false
+	}
+
+	if !ok {
+		m = &providers.DeviceMetadata{}
+		f.devices[ID] = m
+	}
+
+	m.FirstSeen = 1
+	return true
+}
+
+func (f *fakeMetadata) Rename(from string, to string) error {
+	f.Lock()
+	defer f.Unlock()
+
+	m, ok := f.devices[from]
+	if !ok || 0 == m.FirstSeen {
+		return errors.New("unknown device")
+	}
+
+	delete(f.devices, from)
+	m.PreviousIDs = append(m.PreviousIDs, from)
+	f.devices[to] = m
+	return nil
+}
+
+// FakeNewMetadata creates a new fake metadata provider.
+func FakeNewMetadata() *fakeMetadata {
+	return &fakeMetadata{devices: make(map[string]*providers.DeviceMetadata)}
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	"sync"
+
 	"github.com/gobwas/glob"
 	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/providers"
@@ -11,18 +13,40 @@ import (
 // IFakeServer adds additional capabilities to a fake server.
 type IFakeServer interface {
 	AddDevice(device *providers.KnownDevice)
+	SetDeviceState(state map[enums.Property]interface{})
+	LastUpdate() *providers.MasterDeviceUpdate
 }
 
 type fakeServer struct {
 	callback func()
 	device   *providers.KnownDevice
+	state    map[enums.Property]interface{}
+
+	sync.Mutex
+	update *providers.MasterDeviceUpdate
 }
 
 func (f *fakeServer) GetDevice(string) *providers.KnownDevice {
 	return f.device
 }
 
-func (f *fakeServer) PushMasterDeviceUpdate(*providers.MasterDeviceUpdate) {
+func (f *fakeServer) GetDeviceState(string) map[enums.Property]interface{} {
+	if nil == f.device {
+		return nil
+	}
+
+	state := make(map[enums.Property]interface{})
+	for k, v := range f.state {
+		state[k] = v
+	}
+
+	return state
+}
+
+func (f *fakeServer) PushMasterDeviceUpdate(update *providers.MasterDeviceUpdate) {
+	f.Lock()
+	defer f.Unlock()
+	f.update = update
 }
 
 func (f *fakeServer) Start() {
@@ -39,6 +63,16 @@ func (f *fakeServer) AddDevice(device *providers.KnownDevice) {
 	f.device = device
 }
 
+func (f *fakeServer) SetDeviceState(state map[enums.Property]interface{}) {
+	f.state = state
+}
+
+func (f *fakeServer) LastUpdate() *providers.MasterDeviceUpdate {
+	f.Lock()
+	defer f.Unlock()
+	return f.update
+}
+
 // FakeNewServer creates a new fake server.
 func FakeNewServer(callback func()) IFakeServer {
 	return &fakeServer{
@@ -13,6 +13,7 @@ type IFakeSettings interface {
 	AddSBCallback(func(...interface{}))
 	AddMasterComponents(groups, externalAPI, triggers []*providers.RawMasterComponent)
 	AddMasterSettings(*providers.MasterSettings)
+	AddTemplates([]*providers.RawMasterComponent)
 }
 
 type fakeSettings struct {
@@ -26,9 +27,12 @@ type fakeSettings struct {
 	storage        providers.IStorageProvider
 	loader         providers.IPluginLoaderProvider
 	groups         []*providers.RawMasterComponent
+	templates      []*providers.RawMasterComponent
 	externalAPI    []*providers.RawMasterComponent
 	triggers       []*providers.RawMasterComponent
 	masterSettings *providers.MasterSettings
+	metadata       providers.IMetadataProvider
+	snapshots      providers.ISnapshotProvider
 }
 
 func (f *fakeSettings) Storage() providers.IStorageProvider {
@@ -39,10 +43,30 @@ func (f *fakeSettings) Storage() providers.IStorageProvider {
 	return FakeNewStorage()
 }
 
+func (f *fakeSettings) Metadata() providers.IMetadataProvider {
+	if nil == f.metadata {
+		f.metadata = FakeNewMetadata()
+	}
+
+	return f.metadata
+}
+
+func (f *fakeSettings) Snapshots() providers.ISnapshotProvider {
+	if nil == f.snapshots {
+		f.snapshots = FakeNewSnapshots()
+	}
+
+	return f.snapshots
+}
+
 func (f *fakeSettings) Groups() []*providers.RawMasterComponent {
 	return f.groups
 }
 
+func (f *fakeSettings) Templates() []*providers.RawMasterComponent {
+	return f.templates
+}
+
 func (f *fakeSettings) ExtendedAPIs() []*providers.RawMasterComponent {
 	return f.externalAPI
 }
@@ -135,6 +159,10 @@ func (f *fakeSettings) AddMasterSettings(m *providers.MasterSettings) {
 	f.masterSettings = m
 }
 
+func (f *fakeSettings) AddTemplates(templates []*providers.RawMasterComponent) {
+	f.templates = templates
+}
+
 // FakeNewSettings creates a new fake settings provider.
 func FakeNewSettings(sbPublish func(string, ...interface{}), isWorker bool,
 	devices []*providers.RawDevice, logCallback func(string)) providers.ISettingsProvider {
@@ -0,0 +1,57 @@
+//+build !release
+
+package mocks
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"go-home.io/x/server/providers"
+)
+
+type fakeSnapshots struct {
+	sync.Mutex
+	pictures map[string][][]byte
+}
+
+func (f *fakeSnapshots) Add(deviceID string, picture []byte) error {
+	f.Lock()
+	defer f.Unlock()
+
+	f.pictures[deviceID] = append(f.pictures[deviceID], picture)
+	return nil
+}
+
+func (f *fakeSnapshots) List(deviceID string) []*providers.Snapshot {
+	f.Lock()
+	defer f.Unlock()
+
+	res := make([]*providers.Snapshot, 0)
+	for ii := len(f.pictures[deviceID]) - 1; ii >= 0; ii-- {
+		res = append(res, &providers.Snapshot{
+			ID:   strconv.Itoa(ii),
+			Time: int64(ii),
+			Size: int64(len(f.pictures[deviceID][ii])),
+		})
+	}
+
+	return res
+}
+
+func (f *fakeSnapshots) Get(deviceID string, snapshotID string) ([]byte, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	id, err := strconv.Atoi(snapshotID)
+	if err != nil || id < 0 || id >= len(f.pictures[deviceID]) {
+		return nil, errors.New("unknown snapshot")
+	}
+
+	return f.pictures[deviceID][id], nil
+}
+
+// FakeNewSnapshots creates a new fake snapshots provider.
+func FakeNewSnapshots() *fakeSnapshots {
+	return &fakeSnapshots{pictures: make(map[string][][]byte)}
+}
@@ -20,6 +20,10 @@ func (*fakeStorage) History(string) map[enums.Property]map[int64]interface{} {
 	return nil
 }
 
+func (*fakeStorage) Rename(string, string) error {
+	return nil
+}
+
 // FakeNewStorage creates a new fake storage provider.
 func FakeNewStorage() *fakeStorage {
 	return &fakeStorage{}
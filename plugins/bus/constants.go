@@ -28,6 +28,14 @@ const (
 	MsgDeviceCommand
 	// MsgEntityLoadStatus describes status of a config entity.
 	MsgEntityLoadStatus
+	// MsgDeviceResync describes request for device's full state sent by master.
+	MsgDeviceResync
+	// MsgChunk describes a part of the large message.
+	MsgChunk
+	// MsgDeviceRemoved describes device removed from the hub, sent by worker.
+	MsgDeviceRemoved
+	// MsgDeviceEvent describes momentary device event, sent by worker.
+	MsgDeviceEvent
 )
 
 const (
@@ -7,9 +7,9 @@ import (
 	"fmt"
 )
 
-const _MessageTypeName = "pingdevice_assignmentdevice_updatedevice_commandentity_load_status"
+const _MessageTypeName = "pingdevice_assignmentdevice_updatedevice_commandentity_load_statusdevice_resyncchunkdevice_removeddevice_event"
 
-var _MessageTypeIndex = [...]uint8{0, 4, 21, 34, 48, 66}
+var _MessageTypeIndex = [...]uint8{0, 4, 21, 34, 48, 66, 79, 84, 98, 110}
 
 func (i MessageType) String() string {
 	if i < 0 || i >= MessageType(len(_MessageTypeIndex)-1) {
@@ -18,14 +18,18 @@ func (i MessageType) String() string {
 	return _MessageTypeName[_MessageTypeIndex[i]:_MessageTypeIndex[i+1]]
 }
 
-var _MessageTypeValues = []MessageType{0, 1, 2, 3, 4}
+var _MessageTypeValues = []MessageType{0, 1, 2, 3, 4, 5, 6, 7, 8}
 
 var _MessageTypeNameToValueMap = map[string]MessageType{
-	_MessageTypeName[0:4]:   0,
-	_MessageTypeName[4:21]:  1,
-	_MessageTypeName[21:34]: 2,
-	_MessageTypeName[34:48]: 3,
-	_MessageTypeName[48:66]: 4,
+	_MessageTypeName[0:4]:    0,
+	_MessageTypeName[4:21]:   1,
+	_MessageTypeName[21:34]:  2,
+	_MessageTypeName[34:48]:  3,
+	_MessageTypeName[48:66]:  4,
+	_MessageTypeName[66:79]:  5,
+	_MessageTypeName[79:84]:  6,
+	_MessageTypeName[84:98]:  7,
+	_MessageTypeName[98:110]: 8,
 }
 
 // MessageTypeString retrieves an enum value from the enum constants string name.
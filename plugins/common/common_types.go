@@ -1,7 +1,10 @@
 // Package common contains shared data available for all plugins.
 package common
 
-import "image/color"
+import (
+	"image/color"
+	"math"
+)
 
 // Color defines color parameter type.
 type Color struct {
@@ -30,6 +33,86 @@ func (c *Color) Color() color.Color {
 	}
 }
 
+// HSV returns color in hue, saturation and value form.
+func (c *Color) HSV() HSV {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+
+	h := 0.0
+	switch {
+	case 0 == delta:
+		h = 0
+	case max == r:
+		h = 60 * math.Mod((g-b)/delta, 6)
+	case max == g:
+		h = 60 * ((b-r)/delta + 2)
+	default:
+		h = 60 * ((r-g)/delta + 4)
+	}
+
+	if h < 0 {
+		h += 360
+	}
+
+	s := 0.0
+	if max > 0 {
+		s = delta / max
+	}
+
+	return HSV{
+		H: h,
+		S: s * 100,
+		V: max * 100,
+	}
+}
+
+// HSV defines color parameter type in hue, saturation and value form.
+// Hue is in degrees, saturation and value are in percents.
+type HSV struct {
+	H float64 `json:"h" yaml:"h"`
+	S float64 `json:"s" yaml:"s"`
+	V float64 `json:"v" yaml:"v"`
+}
+
+// RGB returns color in RGB form.
+func (c *HSV) RGB() Color {
+	h := math.Mod(c.H, 360)
+	if h < 0 {
+		h += 360
+	}
+
+	s := math.Max(0, math.Min(c.S, 100)) / 100
+	v := math.Max(0, math.Min(c.V, 100)) / 100
+
+	chroma := v * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - chroma
+
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return Color{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+	}
+}
+
 // Int defines simple integer parameter type.
 type Int struct {
 	Value int `json:"value" validate:"required"`
@@ -45,7 +128,18 @@ type String struct {
 	Value string `json:"value" validate:"required"`
 }
 
+// Bool defines simple boolean parameter type.
+type Bool struct {
+	Value bool `json:"value"`
+}
+
 // Percent defines percent parameter type.
 type Percent struct {
 	Value uint8 `json:"value" validate:"required,percent"`
 }
+
+// Position defines cover position, tilt or volume parameter type.
+// Zero is a valid value, which means fully closed cover or muted volume.
+type Position struct {
+	Value *uint8 `json:"value" validate:"required,percent"`
+}
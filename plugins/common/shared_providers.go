@@ -31,17 +31,30 @@ type IPluginLoggerProvider interface {
 }
 
 // MsgDeviceUpdate contains data with updates device's state.
+// Removed flag is set when device is no longer served by any worker.
 type MsgDeviceUpdate struct {
 	ID        string
 	Name      string
 	State     map[enums.Property]interface{}
 	FirstSeen bool
+	Removed   bool
 	Type      enums.DeviceType
 }
 
+// MsgDeviceEvent contains momentary device event.
+type MsgDeviceEvent struct {
+	ID    string
+	Name  string
+	Type  enums.DeviceType
+	Event enums.DeviceEvent
+	Data  map[string]interface{}
+}
+
 // IFanOutProvider defines interface used for distributing
-// device updates even across all system.
+// device updates and events even across all system.
 type IFanOutProvider interface {
 	SubscribeDeviceUpdates() (int64, chan *MsgDeviceUpdate)
 	UnSubscribeDeviceUpdates(int64)
+	SubscribeDeviceEvents() (int64, chan *MsgDeviceEvent)
+	UnSubscribeDeviceEvents(int64)
 }
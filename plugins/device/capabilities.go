@@ -0,0 +1,27 @@
+package device
+
+import (
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Capabilities contains optional metadata about device properties and commands.
+type Capabilities struct {
+	Properties map[enums.Property]*Capability `json:"properties,omitempty"`
+	Commands   map[enums.Command]*Capability  `json:"commands,omitempty"`
+}
+
+// Capability describes allowed values of a single property or command argument.
+// Range is validated only if Max is greater than Min.
+type Capability struct {
+	Min      float64  `json:"min,omitempty"`
+	Max      float64  `json:"max,omitempty"`
+	Step     float64  `json:"step,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	ReadOnly bool     `json:"read_only,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+}
+
+// HasRange checks whether capability has a range defined.
+func (c *Capability) HasRange() bool {
+	return c.Max > c.Min
+}
@@ -0,0 +1,30 @@
+package device
+
+import (
+	"reflect"
+
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// ICover defines window covering device type.
+type ICover interface {
+	IDevice
+	Load() (*CoverState, error)
+	Update() (*CoverState, error)
+	Open() error
+	Close() error
+	Stop() error
+	SetPosition(common.Position) error
+	SetTilt(common.Position) error
+}
+
+// CoverState describes cover state.
+type CoverState struct {
+	CoverStatus enums.CoverStatus `json:"cover_status"`
+	Position    uint8             `json:"position"`
+	Tilt        uint8             `json:"tilt"`
+}
+
+// TypeCover is a syntax sugar around ICover type.
+var TypeCover = reflect.TypeOf((*ICover)(nil)).Elem()
@@ -22,6 +22,7 @@ type Spec struct {
 	SupportedCommands      []enums.Command
 	SupportedProperties    []enums.Property
 	PostCommandDeferUpdate time.Duration
+	Capabilities           *Capabilities
 }
 
 // StateUpdateData contains updated state of the device.
@@ -29,6 +30,13 @@ type StateUpdateData struct {
 	State interface{}
 }
 
+// EventData contains momentary device event, e.g. button click.
+// Data is optional and is delivered as is.
+type EventData struct {
+	Event enums.DeviceEvent
+	Data  map[string]interface{}
+}
+
 // DiscoveredDevices contains information of a newly discovered devices.
 type DiscoveredDevices struct {
 	Type      enums.DeviceType
@@ -36,6 +44,13 @@ type DiscoveredDevices struct {
 	State     interface{}
 }
 
+// RemovedDevice contains information of a device, removed from the hub.
+// Name should match GetName() of the previously discovered device.
+type RemovedDevice struct {
+	Type enums.DeviceType
+	Name string
+}
+
 // InitDataDevice has data required for initializing a new device.
 type InitDataDevice struct {
 	Logger common.ILoggerProvider
@@ -43,5 +58,7 @@ type InitDataDevice struct {
 	UOM    enums.UOM
 
 	DeviceStateUpdateChan chan *StateUpdateData
+	DeviceEventChan       chan *EventData
 	DeviceDiscoveredChan  chan *DiscoveredDevices
+	DeviceRemovedChan     chan *RemovedDevice
 }
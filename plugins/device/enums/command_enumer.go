@@ -7,9 +7,9 @@ import (
 	"fmt"
 )
 
-const _CommandName = "onofftoggleset-colorset-sceneset-brightnessset-transition-timepausedockfind-meset-fan-speedtake-picture"
+const _CommandName = "onofftoggleset-colorset-sceneset-brightnessset-transition-timepausedockfind-meset-fan-speedtake-pictureset-temperatureset-modeset-fan-modelockunlockopenclosestopset-positionset-tiltplaynextpreviousset-volumemuteselect-sourceset-presetset-oscillationset-directionset-color-temperature"
 
-var _CommandIndex = [...]uint8{0, 2, 5, 11, 20, 29, 43, 62, 67, 71, 78, 91, 103}
+var _CommandIndex = [...]uint16{0, 2, 5, 11, 20, 29, 43, 62, 67, 71, 78, 91, 103, 118, 126, 138, 142, 148, 152, 157, 161, 173, 181, 185, 189, 197, 207, 211, 224, 234, 249, 262, 283}
 
 func (i Command) String() string {
 	if i < 0 || i >= Command(len(_CommandIndex)-1) {
@@ -18,21 +18,41 @@ func (i Command) String() string {
 	return _CommandName[_CommandIndex[i]:_CommandIndex[i+1]]
 }
 
-var _CommandValues = []Command{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+var _CommandValues = []Command{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}
 
 var _CommandNameToValueMap = map[string]Command{
-	_CommandName[0:2]:    0,
-	_CommandName[2:5]:    1,
-	_CommandName[5:11]:   2,
-	_CommandName[11:20]:  3,
-	_CommandName[20:29]:  4,
-	_CommandName[29:43]:  5,
-	_CommandName[43:62]:  6,
-	_CommandName[62:67]:  7,
-	_CommandName[67:71]:  8,
-	_CommandName[71:78]:  9,
-	_CommandName[78:91]:  10,
-	_CommandName[91:103]: 11,
+	_CommandName[0:2]:     0,
+	_CommandName[2:5]:     1,
+	_CommandName[5:11]:    2,
+	_CommandName[11:20]:   3,
+	_CommandName[20:29]:   4,
+	_CommandName[29:43]:   5,
+	_CommandName[43:62]:   6,
+	_CommandName[62:67]:   7,
+	_CommandName[67:71]:   8,
+	_CommandName[71:78]:   9,
+	_CommandName[78:91]:   10,
+	_CommandName[91:103]:  11,
+	_CommandName[103:118]: 12,
+	_CommandName[118:126]: 13,
+	_CommandName[126:138]: 14,
+	_CommandName[138:142]: 15,
+	_CommandName[142:148]: 16,
+	_CommandName[148:152]: 17,
+	_CommandName[152:157]: 18,
+	_CommandName[157:161]: 19,
+	_CommandName[161:173]: 20,
+	_CommandName[173:181]: 21,
+	_CommandName[181:185]: 22,
+	_CommandName[185:189]: 23,
+	_CommandName[189:197]: 24,
+	_CommandName[197:207]: 25,
+	_CommandName[207:211]: 26,
+	_CommandName[211:224]: 27,
+	_CommandName[224:234]: 28,
+	_CommandName[234:249]: 29,
+	_CommandName[249:262]: 30,
+	_CommandName[262:283]: 31,
 }
 
 // CommandString retrieves an enum value from the enum constants string name.
@@ -33,16 +33,62 @@ const (
 	CmdSetFanSpeed
 	// CmdTakePicture describes taking a picture.
 	CmdTakePicture
+	// CmdSetTemperature describes setting target temperature command.
+	CmdSetTemperature
+	// CmdSetMode describes setting operation mode command.
+	CmdSetMode
+	// CmdSetFanMode describes setting fan mode command.
+	CmdSetFanMode
+	// CmdLock describes locking command.
+	CmdLock
+	// CmdUnlock describes unlocking command.
+	CmdUnlock
+	// CmdOpen describes opening the latch command.
+	CmdOpen
+	// CmdClose describes closing command.
+	CmdClose
+	// CmdStop describes stopping current movement command.
+	CmdStop
+	// CmdSetPosition describes setting position command.
+	CmdSetPosition
+	// CmdSetTilt describes setting tilt command.
+	CmdSetTilt
+	// CmdPlay describes start playing command.
+	CmdPlay
+	// CmdNext describes switching to the next track command.
+	CmdNext
+	// CmdPrevious describes switching to the previous track command.
+	CmdPrevious
+	// CmdSetVolume describes setting volume command.
+	CmdSetVolume
+	// CmdMute describes toggling mute command.
+	CmdMute
+	// CmdSelectSource describes selecting input source command.
+	CmdSelectSource
+	// CmdSetPreset describes setting preset command.
+	CmdSetPreset
+	// CmdSetOscillation describes enabling or disabling oscillation command.
+	CmdSetOscillation
+	// CmdSetDirection describes setting rotation direction command.
+	CmdSetDirection
+	// CmdSetColorTemperature describes setting white color temperature command.
+	CmdSetColorTemperature
 )
 
 // AllowedCommands contains set of all possible allowed commands per device type.
 var AllowedCommands = map[DeviceType][]Command{
-	DevHub:    {},
-	DevLight:  {CmdToggle, CmdOn, CmdOff, CmdSetColor, CmdSetTransitionTime, CmdSetBrightness, CmdSetScene},
-	DevSwitch: {CmdToggle, CmdOn, CmdOff},
-	DevSensor: {},
-	DevVacuum: {CmdOn, CmdOff, CmdPause, CmdDock, CmdFindMe, CmdSetFanSpeed},
-	DevCamera: {CmdTakePicture},
+	DevHub: {},
+	DevLight: {CmdToggle, CmdOn, CmdOff, CmdSetColor, CmdSetTransitionTime, CmdSetBrightness, CmdSetScene,
+		CmdSetColorTemperature},
+	DevSwitch:      {CmdToggle, CmdOn, CmdOff},
+	DevSensor:      {},
+	DevVacuum:      {CmdOn, CmdOff, CmdPause, CmdDock, CmdFindMe, CmdSetFanSpeed},
+	DevCamera:      {CmdTakePicture},
+	DevThermostat:  {CmdSetTemperature, CmdSetMode, CmdSetFanMode},
+	DevLock:        {CmdLock, CmdUnlock, CmdOpen},
+	DevCover:       {CmdOpen, CmdClose, CmdStop, CmdSetPosition, CmdSetTilt},
+	DevMediaPlayer: {CmdPlay, CmdPause, CmdNext, CmdPrevious, CmdSetVolume, CmdMute, CmdSelectSource},
+	DevFan:         {CmdOn, CmdOff, CmdToggle, CmdSetFanSpeed, CmdSetPreset, CmdSetOscillation, CmdSetDirection},
 }
 
 // SliceContainsCommand checks whether slice contains certain command.
@@ -0,0 +1,21 @@
+//go:generate enumer -type=CoverStatus -transform=snake -trimprefix=Cover -json -text -yaml
+
+package enums
+
+// CoverStatus defines cover device status.
+type CoverStatus int
+
+const (
+	// CoverUnknown describes unknown status.
+	CoverUnknown CoverStatus = iota
+	// CoverOpen describes an opened cover.
+	CoverOpen
+	// CoverClosed describes a closed cover.
+	CoverClosed
+	// CoverOpening describes a cover which is opening.
+	CoverOpening
+	// CoverClosing describes a cover which is closing.
+	CoverClosing
+	// CoverStopped describes a cover which was stopped in the middle.
+	CoverStopped
+)
@@ -0,0 +1,20 @@
+//go:generate enumer -type=DeviceEvent -transform=kebab -trimprefix=Evt -json -text -yaml
+
+package enums
+
+// DeviceEvent describes enum with known momentary device events.
+// Events are not stored as a device state.
+type DeviceEvent int
+
+const (
+	// EvtClick describes single button click.
+	EvtClick DeviceEvent = iota
+	// EvtDoubleClick describes double button click.
+	EvtDoubleClick
+	// EvtLongPress describes long button press.
+	EvtLongPress
+	// EvtRing describes doorbell ring.
+	EvtRing
+	// EvtMotion describes motion, detected by a camera.
+	EvtMotion
+)
@@ -24,6 +24,16 @@ const (
 	DevVacuum
 	// DevCamera describes camera device.
 	DevCamera
+	// DevThermostat describes thermostat device.
+	DevThermostat
+	// DevLock describes lock device.
+	DevLock
+	// DevCover describes window covering device.
+	DevCover
+	// DevMediaPlayer describes media player device.
+	DevMediaPlayer
+	// DevFan describes fan device.
+	DevFan
 )
 
 // SliceContainsDeviceType is a helper Slice.contains.
@@ -7,9 +7,9 @@ import (
 	"fmt"
 )
 
-const _DeviceTypeName = "unknownhublightswitchsensorgroupweathervacuumcamera"
+const _DeviceTypeName = "unknownhublightswitchsensorgroupweathervacuumcamerathermostatlockcovermedia-playerfan"
 
-var _DeviceTypeIndex = [...]uint8{0, 7, 10, 15, 21, 27, 32, 39, 45, 51}
+var _DeviceTypeIndex = [...]uint8{0, 7, 10, 15, 21, 27, 32, 39, 45, 51, 61, 65, 70, 82, 85}
 
 func (i DeviceType) String() string {
 	if i < 0 || i >= DeviceType(len(_DeviceTypeIndex)-1) {
@@ -18,7 +18,7 @@ func (i DeviceType) String() string {
 	return _DeviceTypeName[_DeviceTypeIndex[i]:_DeviceTypeIndex[i+1]]
 }
 
-var _DeviceTypeValues = []DeviceType{0, 1, 2, 3, 4, 5, 6, 7, 8}
+var _DeviceTypeValues = []DeviceType{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
 
 var _DeviceTypeNameToValueMap = map[string]DeviceType{
 	_DeviceTypeName[0:7]:   0,
@@ -30,6 +30,11 @@ var _DeviceTypeNameToValueMap = map[string]DeviceType{
 	_DeviceTypeName[32:39]: 6,
 	_DeviceTypeName[39:45]: 7,
 	_DeviceTypeName[45:51]: 8,
+	_DeviceTypeName[51:61]: 9,
+	_DeviceTypeName[61:65]: 10,
+	_DeviceTypeName[65:70]: 11,
+	_DeviceTypeName[70:82]: 12,
+	_DeviceTypeName[82:85]: 13,
 }
 
 // DeviceTypeString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,15 @@
+//go:generate enumer -type=FanDirection -transform=snake -trimprefix=Direction -json -text -yaml
+
+package enums
+
+// FanDirection defines fan rotation direction.
+type FanDirection int
+
+const (
+	// DirectionUnknown describes unknown direction.
+	DirectionUnknown FanDirection = iota
+	// DirectionForward describes forward rotation.
+	DirectionForward
+	// DirectionReverse describes reverse rotation.
+	DirectionReverse
+)
@@ -0,0 +1,23 @@
+//go:generate enumer -type=LockStatus -transform=snake -trimprefix=Lock -json -text -yaml
+
+package enums
+
+// LockStatus defines lock device status.
+type LockStatus int
+
+const (
+	// LockUnknown describes unknown status.
+	LockUnknown LockStatus = iota
+	// LockLocked describes a locked lock.
+	LockLocked
+	// LockUnlocked describes an unlocked lock.
+	LockUnlocked
+	// LockLocking describes a lock in a locking state.
+	LockLocking
+	// LockUnlocking describes a lock in an unlocking state.
+	LockUnlocking
+	// LockJammed describes a jammed lock.
+	LockJammed
+	// LockOpen describes a lock with opened latch.
+	LockOpen
+)
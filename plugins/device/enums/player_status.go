@@ -0,0 +1,19 @@
+//go:generate enumer -type=PlayerStatus -transform=snake -trimprefix=Player -json -text -yaml
+
+package enums
+
+// PlayerStatus defines media player device status.
+type PlayerStatus int
+
+const (
+	// PlayerUnknown describes unknown status.
+	PlayerUnknown PlayerStatus = iota
+	// PlayerOff describes a turned off media player.
+	PlayerOff
+	// PlayerIdle describes a media player which is not playing anything.
+	PlayerIdle
+	// PlayerPlaying describes a media player which is playing.
+	PlayerPlaying
+	// PlayerPaused describes a paused media player.
+	PlayerPaused
+)
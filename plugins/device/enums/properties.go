@@ -60,12 +60,56 @@ const (
 	PropDistance
 	// PropUser describes user name.
 	PropUser
+	// PropTargetTemperature describes desired temperature.
+	PropTargetTemperature
+	// PropHvacMode describes thermostat operation mode.
+	PropHvacMode
+	// PropFanMode describes thermostat fan mode.
+	PropFanMode
+	// PropHvacAction describes current thermostat action.
+	PropHvacAction
+	// PropLockStatus describes lock status.
+	PropLockStatus
+	// PropCoverStatus describes cover moving status.
+	PropCoverStatus
+	// PropPosition describes cover position.
+	PropPosition
+	// PropTilt describes cover tilt.
+	PropTilt
+	// PropPlayerStatus describes media player status.
+	PropPlayerStatus
+	// PropVolume describes volume level.
+	PropVolume
+	// PropMuted describes muted status.
+	PropMuted
+	// PropSource describes current input source.
+	PropSource
+	// PropSources describes list of input sources available for the device.
+	PropSources
+	// PropTitle describes current media title.
+	PropTitle
+	// PropArtist describes current media artist.
+	PropArtist
+	// PropArtwork describes current media artwork URL.
+	PropArtwork
+	// PropPreset describes current preset.
+	PropPreset
+	// PropPresets describes list of presets available for the device.
+	PropPresets
+	// PropOscillating describes oscillation status.
+	PropOscillating
+	// PropDirection describes rotation direction.
+	PropDirection
+	// PropAvailable describes device availability, it's tracked by the master for all devices.
+	PropAvailable
+	// PropColorTemperature describes white color temperature in kelvins.
+	PropColorTemperature
 )
 
 // AllowedProperties contains set of all possible allowed properties per device type.
 var AllowedProperties = map[DeviceType][]Property{
 	DevHub:    {PropNumDevices},
-	DevLight:  {PropOn, PropColor, PropTransitionTime, PropBrightness, PropScenes},
+	DevLight:  {PropOn, PropColor, PropTransitionTime, PropBrightness, PropScenes, PropColorTemperature},
 	DevSwitch: {PropOn, PropPower},
 	DevSensor: {PropSensorType, PropOn, PropBatteryLevel, PropPower, PropTemperature, PropHumidity, PropPressure,
 		PropClick, PropDoubleClick, PropPress, PropUser},
@@ -73,6 +117,13 @@ var AllowedProperties = map[DeviceType][]Property{
 		PropVisibility, PropWindDirection, PropWindSpeed},
 	DevVacuum: {PropVacStatus, PropBatteryLevel, PropArea, PropDuration, PropFanSpeed},
 	DevCamera: {PropPicture, PropDistance},
+	DevThermostat: {PropTemperature, PropTargetTemperature, PropHumidity, PropHvacMode, PropFanMode,
+		PropHvacAction},
+	DevLock:  {PropLockStatus, PropBatteryLevel, PropUser},
+	DevCover: {PropCoverStatus, PropPosition, PropTilt},
+	DevMediaPlayer: {PropPlayerStatus, PropVolume, PropMuted, PropSource, PropSources, PropTitle, PropArtist,
+		PropArtwork},
+	DevFan: {PropOn, PropFanSpeed, PropPreset, PropPresets, PropOscillating, PropDirection},
 }
 
 // SliceContainsProperty checks whether slice contains certain property.
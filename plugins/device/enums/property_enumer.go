@@ -7,9 +7,9 @@ import (
 	"fmt"
 )
 
-const _PropertyName = "oncolornum_devicestransition_timebrightnessscenespowertemperaturebattery_levelsunrisesunsethumiditypressurevisibilitywind_directionwind_speedclickdouble_clickpresssensor_typevac_statusareadurationfan_speedpicturedistanceuser"
+const _PropertyName = "oncolornum_devicestransition_timebrightnessscenespowertemperaturebattery_levelsunrisesunsethumiditypressurevisibilitywind_directionwind_speedclickdouble_clickpresssensor_typevac_statusareadurationfan_speedpicturedistanceusertarget_temperaturehvac_modefan_modehvac_actionlock_statuscover_statuspositiontiltplayer_statusvolumemutedsourcesourcestitleartistartworkpresetpresetsoscillatingdirectionavailablecolor_temperature"
 
-var _PropertyIndex = [...]uint8{0, 2, 7, 18, 33, 43, 49, 54, 65, 78, 85, 91, 99, 107, 117, 131, 141, 146, 158, 163, 174, 184, 188, 196, 205, 212, 220, 224}
+var _PropertyIndex = [...]uint16{0, 2, 7, 18, 33, 43, 49, 54, 65, 78, 85, 91, 99, 107, 117, 131, 141, 146, 158, 163, 174, 184, 188, 196, 205, 212, 220, 224, 242, 251, 259, 270, 281, 293, 301, 305, 318, 324, 329, 335, 342, 347, 353, 360, 366, 373, 384, 393, 402, 419}
 
 func (i Property) String() string {
 	if i < 0 || i >= Property(len(_PropertyIndex)-1) {
@@ -18,7 +18,7 @@ func (i Property) String() string {
 	return _PropertyName[_PropertyIndex[i]:_PropertyIndex[i+1]]
 }
 
-var _PropertyValues = []Property{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26}
+var _PropertyValues = []Property{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48}
 
 var _PropertyNameToValueMap = map[string]Property{
 	_PropertyName[0:2]:     0,
@@ -48,6 +48,28 @@ var _PropertyNameToValueMap = map[string]Property{
 	_PropertyName[205:212]: 24,
 	_PropertyName[212:220]: 25,
 	_PropertyName[220:224]: 26,
+	_PropertyName[224:242]: 27,
+	_PropertyName[242:251]: 28,
+	_PropertyName[251:259]: 29,
+	_PropertyName[259:270]: 30,
+	_PropertyName[270:281]: 31,
+	_PropertyName[281:293]: 32,
+	_PropertyName[293:301]: 33,
+	_PropertyName[301:305]: 34,
+	_PropertyName[305:318]: 35,
+	_PropertyName[318:324]: 36,
+	_PropertyName[324:329]: 37,
+	_PropertyName[329:335]: 38,
+	_PropertyName[335:342]: 39,
+	_PropertyName[342:347]: 40,
+	_PropertyName[347:353]: 41,
+	_PropertyName[353:360]: 42,
+	_PropertyName[360:366]: 43,
+	_PropertyName[366:373]: 44,
+	_PropertyName[373:384]: 45,
+	_PropertyName[384:393]: 46,
+	_PropertyName[393:402]: 47,
+	_PropertyName[402:419]: 48,
 }
 
 // PropertyString retrieves an enum value from the enum constants string name.
@@ -0,0 +1,63 @@
+//go:generate enumer -type=HvacMode -transform=snake -trimprefix=HvacMode -json -text -yaml
+//go:generate enumer -type=FanMode -transform=snake -trimprefix=FanMode -json -text -yaml
+//go:generate enumer -type=HvacAction -transform=snake -trimprefix=HvacAction -json -text -yaml
+
+package enums
+
+// HvacMode defines thermostat operation mode.
+type HvacMode int
+
+const (
+	// HvacModeUnknown describes unknown mode.
+	HvacModeUnknown HvacMode = iota
+	// HvacModeOff describes a thermostat which is turned off.
+	HvacModeOff
+	// HvacModeHeat describes heating mode.
+	HvacModeHeat
+	// HvacModeCool describes cooling mode.
+	HvacModeCool
+	// HvacModeAuto describes automatic heating or cooling mode.
+	HvacModeAuto
+	// HvacModeDry describes drying mode.
+	HvacModeDry
+	// HvacModeFanOnly describes fan only mode.
+	HvacModeFanOnly
+)
+
+// FanMode defines thermostat fan mode.
+type FanMode int
+
+const (
+	// FanModeUnknown describes unknown fan mode.
+	FanModeUnknown FanMode = iota
+	// FanModeAuto describes fan which works only when required.
+	FanModeAuto
+	// FanModeOn describes fan which works all the time.
+	FanModeOn
+	// FanModeLow describes fan working at low speed.
+	FanModeLow
+	// FanModeMedium describes fan working at medium speed.
+	FanModeMedium
+	// FanModeHigh describes fan working at high speed.
+	FanModeHigh
+)
+
+// HvacAction defines current thermostat action.
+type HvacAction int
+
+const (
+	// HvacActionUnknown describes unknown action.
+	HvacActionUnknown HvacAction = iota
+	// HvacActionOff describes a thermostat which is turned off.
+	HvacActionOff
+	// HvacActionIdle describes a thermostat which is not doing anything at the moment.
+	HvacActionIdle
+	// HvacActionHeating describes a thermostat which is heating.
+	HvacActionHeating
+	// HvacActionCooling describes a thermostat which is cooling.
+	HvacActionCooling
+	// HvacActionDrying describes a thermostat which is drying.
+	HvacActionDrying
+	// HvacActionFan describes a thermostat running only fan.
+	HvacActionFan
+)
@@ -0,0 +1,35 @@
+package device
+
+import (
+	"reflect"
+
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// IFan defines fan device type.
+type IFan interface {
+	IDevice
+	Load() (*FanState, error)
+	Update() (*FanState, error)
+	On() error
+	Off() error
+	Toggle() error
+	SetFanSpeed(common.Percent) error
+	SetPreset(common.String) error
+	SetOscillation(common.Bool) error
+	SetDirection(common.String) error
+}
+
+// FanState describes fan state.
+type FanState struct {
+	On          bool               `json:"on"`
+	FanSpeed    uint8              `json:"fan_speed"`
+	Preset      string             `json:"preset"`
+	Presets     []string           `json:"presets"`
+	Oscillating bool               `json:"oscillating"`
+	Direction   enums.FanDirection `json:"direction"`
+}
+
+// TypeFan is a syntax sugar around IFan type.
+var TypeFan = reflect.TypeOf((*IFan)(nil)).Elem()
@@ -10,9 +10,11 @@ type IHub interface {
 }
 
 // HubLoadResult returns information about known devices for the hub.
+// Removed contains devices which are no longer served by the hub.
 type HubLoadResult struct {
 	State   *HubState
 	Devices []*DiscoveredDevices
+	Removed []*RemovedDevice
 }
 
 // HubState contains device state data.
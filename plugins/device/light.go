@@ -27,6 +27,7 @@ type LightState struct {
 	On                bool         `json:"on"`
 	Color             common.Color `json:"color"`
 	Scenes            []string     `json:"scenes"`
+	ColorTemperature  int          `json:"color_temperature"`
 }
 
 // GradualBrightness defines request for gradual brightness increase.
@@ -0,0 +1,27 @@
+package device
+
+import (
+	"reflect"
+
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// ILock defines lock device type.
+type ILock interface {
+	IDevice
+	Load() (*LockState, error)
+	Update() (*LockState, error)
+	Lock() error
+	Unlock() error
+	Open() error
+}
+
+// LockState describes lock state.
+type LockState struct {
+	LockStatus   enums.LockStatus `json:"lock_status"`
+	BatteryLevel uint8            `json:"battery_level"`
+	User         string           `json:"user"`
+}
+
+// TypeLock is a syntax sugar around ILock type.
+var TypeLock = reflect.TypeOf((*ILock)(nil)).Elem()
@@ -0,0 +1,38 @@
+package device
+
+import (
+	"reflect"
+
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// IMediaPlayer defines media player device type.
+// Mute toggles muted state.
+type IMediaPlayer interface {
+	IDevice
+	Load() (*MediaPlayerState, error)
+	Update() (*MediaPlayerState, error)
+	Play() error
+	Pause() error
+	Next() error
+	Previous() error
+	SetVolume(common.Position) error
+	Mute() error
+	SelectSource(common.String) error
+}
+
+// MediaPlayerState describes media player state.
+type MediaPlayerState struct {
+	PlayerStatus enums.PlayerStatus `json:"player_status"`
+	Volume       uint8              `json:"volume"`
+	Muted        bool               `json:"muted"`
+	Source       string             `json:"source"`
+	Sources      []string           `json:"sources"`
+	Title        string             `json:"title"`
+	Artist       string             `json:"artist"`
+	Artwork      string             `json:"artwork"`
+}
+
+// TypeMediaPlayer is a syntax sugar around IMediaPlayer type.
+var TypeMediaPlayer = reflect.TypeOf((*IMediaPlayer)(nil)).Elem()
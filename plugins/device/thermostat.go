@@ -0,0 +1,31 @@
+package device
+
+import (
+	"reflect"
+
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// IThermostat defines thermostat device type.
+type IThermostat interface {
+	IDevice
+	Load() (*ThermostatState, error)
+	Update() (*ThermostatState, error)
+	SetTemperature(common.Float) error
+	SetMode(common.String) error
+	SetFanMode(common.String) error
+}
+
+// ThermostatState describes thermostat state.
+type ThermostatState struct {
+	Temperature       float64          `json:"temperature"`
+	TargetTemperature float64          `json:"target_temperature"`
+	Humidity          float64          `json:"humidity"`
+	HvacMode          enums.HvacMode   `json:"hvac_mode"`
+	FanMode           enums.FanMode    `json:"fan_mode"`
+	HvacAction        enums.HvacAction `json:"hvac_action"`
+}
+
+// TypeThermostat is a syntax sugar around IThermostat type.
+var TypeThermostat = reflect.TypeOf((*IThermostat)(nil)).Elem()
@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"go-home.io/x/server/plugins/common"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Lowest supported color temperature in kelvins.
+	minColorTemperature = 1000
+	// Highest supported color temperature in kelvins.
+	maxColorTemperature = 40000
+)
+
+// KelvinToMireds converts color temperature from kelvins to mireds.
+func KelvinToMireds(kelvin int) int {
+	if kelvin <= 0 {
+		return 0
+	}
+
+	return int(math.Round(1000000 / float64(kelvin)))
+}
+
+// MiredsToKelvin converts color temperature from mireds to kelvins.
+func MiredsToKelvin(mireds int) int {
+	if mireds <= 0 {
+		return 0
+	}
+
+	return int(math.Round(1000000 / float64(mireds)))
+}
+
+// ColorTemperatureToRGB approximates RGB color of the black body with provided temperature in kelvins.
+func ColorTemperatureToRGB(kelvin int) common.Color {
+	temp := math.Max(minColorTemperature, math.Min(float64(kelvin), maxColorTemperature)) / 100
+
+	var r, g, b float64
+	if temp <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(temp) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(temp-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(temp-60, -0.0755148492)
+	}
+
+	switch {
+	case temp >= 66:
+		b = 255
+	case temp <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(temp-10) - 305.0447927307
+	}
+
+	return common.Color{
+		R: clampColor(r),
+		G: clampColor(g),
+		B: clampColor(b),
+	}
+}
+
+// RGBToColorTemperature approximates correlated color temperature in kelvins.
+// Returns 0 for black color.
+func RGBToColorTemperature(c common.Color) int {
+	r, g, b := linearColor(c.R), linearColor(c.G), linearColor(c.B)
+
+	x := 0.4124*r + 0.3576*g + 0.1805*b
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := 0.0193*r + 0.1192*g + 0.9505*b
+	sum := x + y + z
+	if 0 == sum {
+		return 0
+	}
+
+	n := (x/sum - 0.3320) / (0.1858 - y/sum)
+	cct := 449*math.Pow(n, 3) + 3525*math.Pow(n, 2) + 6823.3*n + 5520.33
+
+	return int(math.Round(math.Max(minColorTemperature, math.Min(cct, maxColorTemperature))))
+}
+
+// ParseColor converts RGB or HSV color, provided in any form, to RGB color.
+func ParseColor(x interface{}) (common.Color, error) {
+	switch v := x.(type) {
+	case common.Color:
+		return v, nil
+	case *common.Color:
+		return *v, nil
+	case common.HSV:
+		return v.RGB(), nil
+	case *common.HSV:
+		return v.RGB(), nil
+	}
+
+	data, err := yaml.Marshal(x)
+	if err != nil {
+		return common.Color{}, errors.Wrap(err, "yaml marshal failed")
+	}
+
+	keys := make(map[string]interface{})
+	err = yaml.Unmarshal(data, &keys)
+	if err != nil {
+		return common.Color{}, errors.Wrap(err, "yaml un-marshal failed")
+	}
+
+	if _, ok := keys["h"]; ok {
+		hsv := common.HSV{}
+		err = yaml.Unmarshal(data, &hsv)
+		return hsv.RGB(), err
+	}
+
+	c := common.Color{}
+	err = yaml.Unmarshal(data, &c)
+	return c, err
+}
+
+// Converts sRGB channel to linear value.
+func linearColor(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// Clamps color channel.
+func clampColor(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(v, 255))))
+}
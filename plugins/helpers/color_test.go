@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Tests RGB and HSV conversion.
+func TestHSV(t *testing.T) {
+	data := []struct {
+		rgb common.Color
+		hsv common.HSV
+	}{
+		{rgb: common.Color{R: 255, G: 0, B: 0}, hsv: common.HSV{H: 0, S: 100, V: 100}},
+		{rgb: common.Color{R: 0, G: 255, B: 0}, hsv: common.HSV{H: 120, S: 100, V: 100}},
+		{rgb: common.Color{R: 0, G: 0, B: 255}, hsv: common.HSV{H: 240, S: 100, V: 100}},
+		{rgb: common.Color{R: 255, G: 255, B: 255}, hsv: common.HSV{H: 0, S: 0, V: 100}},
+		{rgb: common.Color{R: 0, G: 0, B: 0}, hsv: common.HSV{H: 0, S: 0, V: 0}},
+		{rgb: common.Color{R: 255, G: 128, B: 0}, hsv: common.HSV{H: 30.1, S: 100, V: 100}},
+	}
+
+	for _, v := range data {
+		hsv := v.rgb.HSV()
+		assert.InDelta(t, v.hsv.H, hsv.H, 0.1, "h %v", v.rgb)
+		assert.InDelta(t, v.hsv.S, hsv.S, 0.1, "s %v", v.rgb)
+		assert.InDelta(t, v.hsv.V, hsv.V, 0.1, "v %v", v.rgb)
+		assert.Equal(t, v.rgb, hsv.RGB(), "rgb %v", v.rgb)
+	}
+}
+
+// Tests color temperature conversion.
+func TestColorTemperature(t *testing.T) {
+	assert.Equal(t, 370, KelvinToMireds(2700))
+	assert.Equal(t, 2703, MiredsToKelvin(370))
+	assert.Equal(t, 0, KelvinToMireds(0))
+	assert.Equal(t, 0, MiredsToKelvin(-1))
+
+	c := ColorTemperatureToRGB(6600)
+	assert.Equal(t, common.Color{R: 255, G: 255, B: 255}, c)
+
+	c = ColorTemperatureToRGB(2000)
+	assert.True(t, c.R > c.G && c.G > c.B, "warm color")
+
+	for _, v := range []int{2000, 2700, 4000, 6500} {
+		k := RGBToColorTemperature(ColorTemperatureToRGB(v))
+		assert.True(t, math.Abs(float64(k-v))/float64(v) < 0.15, "temperature %d: %d", v, k)
+	}
+
+	assert.Equal(t, 0, RGBToColorTemperature(common.Color{}))
+}
+
+// Tests color parsing.
+func TestParseColor(t *testing.T) {
+	gold := common.Color{R: 255, G: 0, B: 0}
+	data := []interface{}{
+		gold,
+		&gold,
+		common.HSV{H: 0, S: 100, V: 100},
+		map[string]interface{}{"r": 255, "g": 0, "b": 0},
+		map[string]interface{}{"h": 360.0, "s": 100.0, "v": 100.0},
+		map[interface{}]interface{}{"h": 0, "s": 100, "v": 100},
+	}
+
+	for _, v := range data {
+		c, err := ParseColor(v)
+		require.NoError(t, err, "%v", v)
+		assert.Equal(t, gold, c, "%v", v)
+	}
+
+	_, err := ParseColor(map[string]interface{}{"r": "red"})
+	assert.Error(t, err)
+
+	p, err := CommandPropertyFixYaml(map[interface{}]interface{}{"h": 0, "s": 100, "v": 100}, enums.CmdSetColor)
+	assert.NoError(t, err)
+	assert.Equal(t, gold, p)
+}
@@ -38,17 +38,22 @@ func GetPropertyType(p enums.Property) PropertyType {
 	switch p {
 	case enums.PropColor:
 		return PropColor
-	case enums.PropScenes:
+	case enums.PropScenes, enums.PropSources, enums.PropPresets:
 		return PropStringSlice
-	case enums.PropSensorType, enums.PropVacStatus:
+	case enums.PropSensorType, enums.PropVacStatus, enums.PropHvacMode, enums.PropFanMode, enums.PropHvacAction,
+		enums.PropLockStatus, enums.PropCoverStatus, enums.PropPlayerStatus, enums.PropDirection:
 		return PropEnum
-	case enums.PropPicture, enums.PropUser, enums.PropSunrise, enums.PropSunset:
+	case enums.PropPicture, enums.PropUser, enums.PropSunrise, enums.PropSunset, enums.PropSource,
+		enums.PropTitle, enums.PropArtist, enums.PropArtwork, enums.PropPreset:
 		return PropString
-	case enums.PropOn, enums.PropClick, enums.PropDoubleClick, enums.PropPress:
+	case enums.PropOn, enums.PropClick, enums.PropDoubleClick, enums.PropPress, enums.PropMuted,
+		enums.PropOscillating, enums.PropAvailable:
 		return PropBool
-	case enums.PropBrightness, enums.PropBatteryLevel, enums.PropFanSpeed:
+	case enums.PropBrightness, enums.PropBatteryLevel, enums.PropFanSpeed, enums.PropPosition, enums.PropTilt,
+		enums.PropVolume:
 		return PropPercent
-	case enums.PropDuration, enums.PropDistance, enums.PropNumDevices, enums.PropTransitionTime:
+	case enums.PropDuration, enums.PropDistance, enums.PropNumDevices, enums.PropTransitionTime,
+		enums.PropColorTemperature:
 		return PropInt
 	}
 
@@ -164,14 +169,24 @@ func CommandPropertyFixYaml(x interface{}, c enums.Command) (interface{}, error)
 	}
 
 	switch c {
-	case enums.CmdOn, enums.CmdOff, enums.CmdToggle, enums.CmdFindMe, enums.CmdDock, enums.CmdPause:
+	case enums.CmdOn, enums.CmdOff, enums.CmdToggle, enums.CmdFindMe, enums.CmdDock, enums.CmdPause,
+		enums.CmdLock, enums.CmdUnlock, enums.CmdOpen, enums.CmdClose, enums.CmdStop,
+		enums.CmdPlay, enums.CmdNext, enums.CmdPrevious, enums.CmdMute:
 		return nil, nil
 	case enums.CmdSetBrightness, enums.CmdSetFanSpeed:
 		return convertValueProperty(x, &common.Percent{})
-	case enums.CmdSetTransitionTime:
+	case enums.CmdSetPosition, enums.CmdSetTilt, enums.CmdSetVolume:
+		return convertValueProperty(x, &common.Position{})
+	case enums.CmdSetTransitionTime, enums.CmdSetColorTemperature:
 		return convertValueProperty(x, &common.Int{})
+	case enums.CmdSetTemperature:
+		return convertValueProperty(x, &common.Float{})
+	case enums.CmdSetMode, enums.CmdSetFanMode, enums.CmdSelectSource, enums.CmdSetPreset, enums.CmdSetDirection:
+		return convertValueProperty(x, &common.String{})
+	case enums.CmdSetOscillation:
+		return convertValueProperty(x, &common.Bool{})
 	case enums.CmdSetColor:
-		return convertProperty(x, &common.Color{})
+		return ParseColor(x)
 	}
 
 	return x, nil
@@ -184,11 +199,12 @@ func PropertyFixNum(x interface{}, p enums.Property) interface{} {
 	}
 
 	switch p {
-	case enums.PropBatteryLevel, enums.PropBrightness, enums.PropFanSpeed:
+	case enums.PropBatteryLevel, enums.PropBrightness, enums.PropFanSpeed, enums.PropPosition, enums.PropTilt,
+		enums.PropVolume:
 		return uint8(x.(float64))
 	case enums.PropTransitionTime:
 		return uint16(x.(float64))
-	case enums.PropDuration, enums.PropDistance:
+	case enums.PropDuration, enums.PropDistance, enums.PropColorTemperature:
 		return int(x.(float64))
 	}
 
@@ -44,6 +44,18 @@ func TestProperties(t *testing.T) {
 			prop:  enums.PropUser,
 			cmd:   -1,
 		},
+		{
+			input: 0,
+			gold:  common.Percent{Value: 0},
+			prop:  enums.PropPosition,
+			cmd:   -1,
+		},
+		{
+			input: 21.5,
+			gold:  common.Float{Value: 21.5},
+			prop:  enums.PropTargetTemperature,
+			cmd:   enums.CmdSetTemperature,
+		},
 		{
 			input: []string{"s1", "s2"},
 			gold:  []string{"s1", "s2"},
@@ -66,6 +78,34 @@ func TestProperties(t *testing.T) {
 	}
 }
 
+// Tests cover and volume commands conversion.
+func TestPositionCommands(t *testing.T) {
+	for _, v := range []enums.Command{enums.CmdSetPosition, enums.CmdSetTilt, enums.CmdSetVolume} {
+		p, err := CommandPropertyFixYaml(0, v)
+		require.NoError(t, err, "fix yaml %s", v.String())
+		require.IsType(t, common.Position{}, p, "type %s", v.String())
+		require.NotNil(t, p.(common.Position).Value, "value %s", v.String())
+		assert.Equal(t, uint8(0), *p.(common.Position).Value, "equal %s", v.String())
+	}
+}
+
+// Tests string commands conversion.
+func TestStringCommands(t *testing.T) {
+	for _, v := range []enums.Command{enums.CmdSetMode, enums.CmdSetFanMode, enums.CmdSelectSource,
+		enums.CmdSetPreset, enums.CmdSetDirection} {
+		p, err := CommandPropertyFixYaml("auto", v)
+		assert.NoError(t, err, "fix yaml %s", v.String())
+		assert.Equal(t, common.String{Value: "auto"}, p, "equal %s", v.String())
+	}
+}
+
+// Tests boolean commands conversion.
+func TestBoolCommands(t *testing.T) {
+	p, err := CommandPropertyFixYaml(true, enums.CmdSetOscillation)
+	assert.NoError(t, err, "fix yaml")
+	assert.Equal(t, common.Bool{Value: true}, p, "equal")
+}
+
 // Tests unmarshal properties.
 func TestUnmarshalProperty(t *testing.T) {
 	data := []struct {
@@ -114,6 +154,11 @@ func TestPlainProperty(t *testing.T) {
 			prop: enums.PropVacStatus,
 			out:  enums.VacDocked,
 		},
+		{
+			in:   enums.HvacModeHeat,
+			prop: enums.PropHvacMode,
+			out:  enums.HvacModeHeat,
+		},
 		{
 			in:   true,
 			prop: enums.PropOn,
@@ -209,6 +254,16 @@ func TestPropertyFixNum(t *testing.T) {
 			val:  float64(10),
 			out:  uint16(10),
 		},
+		{
+			prop: enums.PropPosition,
+			val:  float64(30),
+			out:  uint8(30),
+		},
+		{
+			prop: enums.PropVolume,
+			val:  float64(70),
+			out:  uint8(70),
+		},
 		{
 			prop: enums.PropDuration,
 			val:  float64(10),
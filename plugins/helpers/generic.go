@@ -34,6 +34,17 @@ func SliceEqualsString(x, y []string) bool {
 	return len(diff) == 0
 }
 
+// SliceRemoveString returns a copy of the slice without the element.
+func SliceRemoveString(s []string, e string) []string {
+	res := make([]string, 0, len(s))
+	for _, a := range s {
+		if a != e {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
 // SliceContainsString slice.contains implementation for strings.
 func SliceContainsString(s []string, e string) bool {
 	for _, a := range s {
@@ -42,6 +42,13 @@ func TestSliceContainsString(t *testing.T) {
 	}
 }
 
+// Tests removal from slice.
+func TestSliceRemoveString(t *testing.T) {
+	assert.Equal(t, []string{"1", "3"}, SliceRemoveString([]string{"1", "2", "3", "2"}, "2"))
+	assert.Equal(t, []string{"1"}, SliceRemoveString([]string{"1"}, "2"))
+	assert.Equal(t, []string{}, SliceRemoveString(nil, "2"))
+}
+
 // Tests slices equality.
 func TestEqualSlices(t *testing.T) {
 	data := []struct {
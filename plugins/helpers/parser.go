@@ -37,10 +37,17 @@ type parserExpression struct {
 func NewParser() ITemplateParser {
 	p := &parser{
 		functions: map[string]govaluate.ExpressionFunction{
-			"jq":  jqParse,
-			"num": float64Convert,
-			"str": strConvert,
-			"fmt": format,
+			"jq":    jqParse,
+			"num":   float64Convert,
+			"str":   strConvert,
+			"fmt":   format,
+			"sum":   aggregateSum,
+			"avg":   aggregateAvg,
+			"min":   aggregateMin,
+			"max":   aggregateMax,
+			"count": aggregateCount,
+			"any":   aggregateAny,
+			"all":   aggregateAll,
 		},
 	}
 
@@ -176,3 +183,181 @@ func format(arguments ...interface{}) (interface{}, error) {
 
 	return fmt.Sprintf(a.(string), arguments[1:]...), nil
 }
+
+// Sums all numeric arguments.
+// Slices are flattened, so params with multiple values are supported.
+func aggregateSum(arguments ...interface{}) (interface{}, error) {
+	values, err := numericValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	res := 0.0
+	for _, v := range values {
+		res += v
+	}
+
+	return res, nil
+}
+
+// Calculates average of all numeric arguments.
+func aggregateAvg(arguments ...interface{}) (interface{}, error) {
+	values, err := numericValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if 0 == len(values) {
+		return nil, &ErrArgumentsMismatch{Count: 0}
+	}
+
+	res := 0.0
+	for _, v := range values {
+		res += v
+	}
+
+	return res / float64(len(values)), nil
+}
+
+// Returns minimal numeric argument.
+func aggregateMin(arguments ...interface{}) (interface{}, error) {
+	values, err := numericValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if 0 == len(values) {
+		return nil, &ErrArgumentsMismatch{Count: 0}
+	}
+
+	res := values[0]
+	for _, v := range values[1:] {
+		if v < res {
+			res = v
+		}
+	}
+
+	return res, nil
+}
+
+// Returns maximal numeric argument.
+func aggregateMax(arguments ...interface{}) (interface{}, error) {
+	values, err := numericValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	if 0 == len(values) {
+		return nil, &ErrArgumentsMismatch{Count: 0}
+	}
+
+	res := values[0]
+	for _, v := range values[1:] {
+		if v > res {
+			res = v
+		}
+	}
+
+	return res, nil
+}
+
+// Returns number of arguments.
+func aggregateCount(arguments ...interface{}) (interface{}, error) {
+	return float64(len(flatten(arguments))), nil
+}
+
+// Returns true if at least one argument is true.
+func aggregateAny(arguments ...interface{}) (interface{}, error) {
+	values, err := boolValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range values {
+		if v {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Returns true if all arguments are true.
+func aggregateAll(arguments ...interface{}) (interface{}, error) {
+	values, err := boolValues(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range values {
+		if !v {
+			return false, nil
+		}
+	}
+
+	return 0 != len(values), nil
+}
+
+// Flattens slices arguments.
+func flatten(arguments []interface{}) []interface{} {
+	res := make([]interface{}, 0)
+	for _, v := range arguments {
+		if nil == v {
+			continue
+		}
+
+		val := reflect.ValueOf(v)
+		if reflect.Slice != val.Kind() {
+			res = append(res, v)
+			continue
+		}
+
+		for ii := 0; ii < val.Len(); ii++ {
+			res = append(res, flatten([]interface{}{val.Index(ii).Interface()})...)
+		}
+	}
+
+	return res
+}
+
+// Converts all arguments into float64.
+func numericValues(arguments []interface{}) ([]float64, error) {
+	res := make([]float64, 0)
+	for _, v := range flatten(arguments) {
+		val := reflect.ValueOf(v)
+		switch val.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			res = append(res, float64(val.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			res = append(res, float64(val.Uint()))
+		case reflect.Float32, reflect.Float64:
+			res = append(res, val.Float())
+		default:
+			return nil, &ErrWrongArgument{Message: "not compatible with float type"}
+		}
+	}
+
+	return res, nil
+}
+
+// Converts all arguments into bool.
+// Numeric values are treated as true if they are not zero.
+func boolValues(arguments []interface{}) ([]bool, error) {
+	res := make([]bool, 0)
+	for _, v := range flatten(arguments) {
+		b, ok := v.(bool)
+		if ok {
+			res = append(res, b)
+			continue
+		}
+
+		n, err := numericValues([]interface{}{v})
+		if err != nil {
+			return nil, &ErrWrongArgument{Message: "not compatible with bool type"}
+		}
+
+		res = append(res, 0 != n[0])
+	}
+
+	return res, nil
+}
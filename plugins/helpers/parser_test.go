@@ -83,6 +83,11 @@ func TestParseErrors(t *testing.T) {
 		"jq('ok', '=<)')",
 		"jq('ok', '.data')",
 		"jq('ok', '1', '1')",
+		"sum('a')",
+		"avg()",
+		"min()",
+		"max()",
+		"any('a')",
 	}
 
 	for _, v := range data {
@@ -183,3 +188,40 @@ func TestParse(t *testing.T) {
 		assert.True(t, PropertyDeepEqual(res, v.expectedResult, v.property), v.expression)
 	}
 }
+
+// Tests aggregation functions.
+func TestAggregations(t *testing.T) {
+	params := map[string]interface{}{
+		"temperature": []interface{}{20.5, 22.5, 20.0},
+		"brightness":  []interface{}{uint8(10), uint8(30)},
+		"on":          []interface{}{false, true},
+		"power":       []interface{}{},
+	}
+
+	data := []struct {
+		expression     string
+		expectedResult interface{}
+	}{
+		{"sum(temperature)", 63.0},
+		{"avg(temperature)", 21.0},
+		{"min(temperature)", 20.0},
+		{"max(temperature, 30)", 30.0},
+		{"avg(brightness)", 20.0},
+		{"count(temperature)", 3.0},
+		{"sum(power)", 0.0},
+		{"any(on)", true},
+		{"all(on)", false},
+		{"all(true, 1)", true},
+		{"any(power)", false},
+		{"any(brightness)", true},
+	}
+
+	p := NewParser()
+	for _, v := range data {
+		exp, err := p.Compile(v.expression)
+		require.NoError(t, err, "compile %s", v.expression)
+		res, err := exp.Format(params)
+		require.NoError(t, err, "format %s", v.expression)
+		assert.Equal(t, v.expectedResult, res, v.expression)
+	}
+}
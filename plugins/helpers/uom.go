@@ -8,7 +8,7 @@ import (
 
 // List of properties, which needs to be converted.
 var convertRequired = []enums.Property{
-	enums.PropTemperature, enums.PropWindSpeed,
+	enums.PropTemperature, enums.PropTargetTemperature, enums.PropWindSpeed,
 	enums.PropVisibility, enums.PropPressure,
 	enums.PropArea,
 }
@@ -50,7 +50,7 @@ func UOMConvert(value float64, property enums.Property, currentUOM enums.UOM, de
 // Converts imperial to metric
 func convertImperialToMetric(value float64, property enums.Property) float64 {
 	switch property {
-	case enums.PropTemperature:
+	case enums.PropTemperature, enums.PropTargetTemperature:
 		return (value - 32.0) / 1.8
 	case enums.PropWindSpeed, enums.PropVisibility:
 		return value / 1.609344
@@ -66,7 +66,7 @@ func convertImperialToMetric(value float64, property enums.Property) float64 {
 // Converts metric to imperial.
 func convertMetricToImperial(value float64, property enums.Property) float64 {
 	switch property {
-	case enums.PropTemperature:
+	case enums.PropTemperature, enums.PropTargetTemperature:
 		return value*1.8 + 32.0
 	case enums.PropWindSpeed, enums.PropVisibility:
 		return 1.609344 * value
@@ -84,7 +84,7 @@ func getUOM(property enums.Property, current string) enums.UOM {
 	current = strings.ToLower(current)
 	isImp := false
 	switch property {
-	case enums.PropTemperature:
+	case enums.PropTemperature, enums.PropTargetTemperature:
 		isImp = "f" == current
 	case enums.PropWindSpeed:
 		isImp = "mph" == current
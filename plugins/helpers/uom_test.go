@@ -27,10 +27,11 @@ func TestUOMConvert(t *testing.T) {
 // Tests strings conversion.
 func TestUOMConvertString(t *testing.T) {
 	str := map[enums.Property]map[enums.UOM]string{
-		enums.PropTemperature: {enums.UOMImperial: "f", enums.UOMMetric: "c"},
-		enums.PropWindSpeed:   {enums.UOMImperial: "mph", enums.UOMMetric: "kmh"},
-		enums.PropVisibility:  {enums.UOMImperial: "mi", enums.UOMMetric: "km"},
-		enums.PropPressure:    {enums.UOMImperial: "inHg", enums.UOMMetric: "mbar"},
+		enums.PropTemperature:       {enums.UOMImperial: "f", enums.UOMMetric: "c"},
+		enums.PropTargetTemperature: {enums.UOMImperial: "f", enums.UOMMetric: "c"},
+		enums.PropWindSpeed:         {enums.UOMImperial: "mph", enums.UOMMetric: "kmh"},
+		enums.PropVisibility:        {enums.UOMImperial: "mi", enums.UOMMetric: "km"},
+		enums.PropPressure:          {enums.UOMImperial: "inHg", enums.UOMMetric: "mbar"},
 	}
 	for _, v := range convertRequired {
 		s, ok := str[v]
@@ -14,6 +14,11 @@ type IStorage interface {
 	History(string, int) map[string]map[int64]interface{}
 }
 
+// IStorageRenamer defines optional storage plugin interface for migrating device history.
+type IStorageRenamer interface {
+	Rename(from string, to string) error
+}
+
 // InitDataStorage has data required for initializing of a new state storage provider.
 type InitDataStorage struct {
 	Logger common.ILoggerProvider
@@ -8,7 +8,11 @@ type IInternalFanOutProvider interface {
 	common.IFanOutProvider
 
 	ChannelInDeviceUpdates() chan *common.MsgDeviceUpdate
+	ChannelInDeviceEvents() chan *common.MsgDeviceEvent
 	SubscribeTriggerUpdates() (int64, chan string)
 	UnSubscribeTriggerUpdates(int64)
 	ChannelInTriggerUpdates() chan string
+	SubscribeMetadataUpdates() (int64, chan string)
+	UnSubscribeMetadataUpdates(int64)
+	ChannelInMetadataUpdates() chan string
 }
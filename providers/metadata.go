@@ -0,0 +1,27 @@
+package providers
+
+import "github.com/gobwas/glob"
+
+// IMetadataProvider defines user-defined devices metadata store.
+// It also keeps track of known device IDs.
+type IMetadataProvider interface {
+	Get(string) *DeviceMetadata
+	All() map[string]*DeviceMetadata
+	Set(string, *DeviceMetadata) error
+	Delete(string) error
+	Match(glob.Glob, string) bool
+	Register(string) bool
+	Rename(from string, to string) error
+}
+
+// DeviceMetadata has user-defined data about the device.
+// FirstSeen and PreviousIDs are maintained by the system.
+type DeviceMetadata struct {
+	Name        string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Icon        string   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Aliases     []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty" yaml:"hidden,omitempty"`
+	FirstSeen   int64    `json:"first_seen,omitempty" yaml:"firstSeen,omitempty"`
+	PreviousIDs []string `json:"previous_ids,omitempty" yaml:"previousIDs,omitempty"`
+}
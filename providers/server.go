@@ -11,6 +11,7 @@ type IServerProvider interface {
 	Start()
 	InternalCommandInvokeDeviceCommand(deviceRegexp glob.Glob, cmd enums.Command, data map[string]interface{})
 	GetDevice(string) *KnownDevice
+	GetDeviceState(string) map[enums.Property]interface{}
 	PushMasterDeviceUpdate(*MasterDeviceUpdate)
 }
 
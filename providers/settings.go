@@ -23,8 +23,11 @@ type ISettingsProvider interface {
 	Triggers() []*RawMasterComponent
 	ExtendedAPIs() []*RawMasterComponent
 	Groups() []*RawMasterComponent
+	Templates() []*RawMasterComponent
 	FanOut() IInternalFanOutProvider
 	Storage() IStorageProvider
+	Metadata() IMetadataProvider
+	Snapshots() ISnapshotProvider
 }
 
 // RawDeviceSelector has data required for understanding
@@ -50,6 +53,9 @@ type MasterSettings struct {
 	Port         int                   `yaml:"port" validate:"required,port" default:"8000"`
 	DelayedStart int                   `yaml:"delayedStart" validate:"gte=0"`
 	UOM          enums.UOM             `yaml:"units" default:"imperial"`
+	BusQueue     BusQueueSettings      `yaml:"busQueue"`
+	Metadata     string                `yaml:"metadata"`
+	Snapshots    SnapshotSettings      `yaml:"snapshots"`
 	Locations    []*RawMasterComponent `yaml:"-"`
 }
 
@@ -58,6 +64,22 @@ type WorkerSettings struct {
 	Name       string            `yaml:"name"`
 	Properties map[string]string `yaml:"properties"`
 	MaxDevices int               `yaml:"maxDevices" validate:"gte=0,lte=1000" default:"99"`
+	BusQueue   BusQueueSettings  `yaml:"busQueue"`
+}
+
+// BusQueueSettings has configuration for incoming service bus messages processing.
+type BusQueueSettings struct {
+	Workers  int    `yaml:"workers" validate:"gte=0,lte=64" default:"4"`
+	Size     int    `yaml:"size" validate:"gte=0" default:"100"`
+	Overflow string `yaml:"overflow" validate:"omitempty,oneof=block drop-newest drop-oldest" default:"block"`
+}
+
+// SnapshotSettings has configuration for camera snapshots archive.
+type SnapshotSettings struct {
+	Enabled  bool   `yaml:"enabled"`
+	Location string `yaml:"location"`
+	MaxCount int    `yaml:"maxCount" validate:"gte=0" default:"100"`
+	MaxAge   int    `yaml:"maxAgeHours" validate:"gte=0" default:"24"`
 }
 
 // RawMasterComponent has configuration for master component.
@@ -0,0 +1,15 @@
+package providers
+
+// ISnapshotProvider defines camera snapshots archive.
+type ISnapshotProvider interface {
+	Add(string, []byte) error
+	List(string) []*Snapshot
+	Get(string, string) ([]byte, error)
+}
+
+// Snapshot describes a single archived camera picture.
+type Snapshot struct {
+	ID   string `json:"id"`
+	Time int64  `json:"time"`
+	Size int64  `json:"size"`
+}
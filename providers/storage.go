@@ -10,4 +10,5 @@ type IStorageProvider interface {
 	Heartbeat(string)
 	State(*common.MsgDeviceUpdate)
 	History(string) map[enums.Property]map[int64]interface{}
+	Rename(from string, to string) error
 }
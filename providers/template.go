@@ -0,0 +1,7 @@
+package providers
+
+// ITemplateProvider describes template device provider.
+type ITemplateProvider interface {
+	ID() string
+	Devices() []string
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+	"go-home.io/x/server/systems/bus"
+)
+
+// Returns the latest camera picture.
+// If camera didn't send any picture yet, waits for the first one.
+//noinspection GoUnhandledErrorResult
+func (s *GoHomeServer) getCameraSnapshot(writer http.ResponseWriter, request *http.Request) {
+	kd := s.getAllowedCamera(writer, request)
+	if nil == kd {
+		return
+	}
+
+	picture := decodePicture(kd.State[enums.PropPicture.String()])
+	if nil == picture {
+		picture = s.waitPicture(writer, request, kd)
+		if nil == picture {
+			return
+		}
+	} else {
+		s.requestPicture(kd)
+	}
+
+	writer.Header().Set("Content-Type", "image/jpeg")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(picture) // nolint: gosec
+}
+
+// Requests a picture and waits for the camera to send it.
+// Subscription is released before response is written.
+func (s *GoHomeServer) waitPicture(writer http.ResponseWriter, request *http.Request, kd *knownDevice) []byte {
+	subID, upd := s.Settings.FanOut().SubscribeDeviceUpdates()
+	defer s.Settings.FanOut().UnSubscribeDeviceUpdates(subID)
+	s.requestPicture(kd)
+
+	timeout := time.After(cameraPictureTimeout * time.Second)
+	for {
+		select {
+		case <-request.Context().Done():
+			return nil
+		case <-timeout:
+			http.Error(writer, "Picture is not available", http.StatusNotFound)
+			return nil
+		case msg, ok := <-upd:
+			if !ok {
+				return nil
+			}
+
+			if msg.ID != kd.ID {
+				break
+			}
+
+			if picture := decodePicture(msg.State[enums.PropPicture]); nil != picture {
+				return picture
+			}
+		}
+	}
+}
+
+// Streams camera pictures as MJPEG until client disconnects.
+//noinspection GoUnhandledErrorResult
+func (s *GoHomeServer) getCameraStream(writer http.ResponseWriter, request *http.Request) {
+	kd := s.getAllowedCamera(writer, request)
+	if nil == kd {
+		return
+	}
+
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		respondError(writer, "Streaming is not supported")
+		return
+	}
+
+	subID, upd := s.Settings.FanOut().SubscribeDeviceUpdates()
+	defer s.Settings.FanOut().UnSubscribeDeviceUpdates(subID)
+	frames := make(chan []byte, 1)
+	go drainFrames(kd.ID, upd, frames)
+	s.requestPicture(kd)
+
+	writer.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+mjpegBoundary)
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.WriteHeader(http.StatusOK)
+
+	if picture := decodePicture(kd.State[enums.PropPicture.String()]); nil != picture {
+		if writeFrame(writer, flusher, picture) != nil {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case picture := <-frames:
+			if writeFrame(writer, flusher, picture) != nil {
+				s.Logger.Debug("Camera stream was closed", common.LogSystemToken, logSystem,
+					common.LogIDToken, kd.ID)
+				return
+			}
+		}
+	}
+}
+
+// Reads device updates until subscription is closed and keeps only the latest camera picture.
+// Slow clients skip frames instead of blocking updates fan-out.
+func drainFrames(deviceID string, upd chan *common.MsgDeviceUpdate, frames chan []byte) {
+	for msg := range upd {
+		if msg.ID != deviceID {
+			continue
+		}
+
+		picture := decodePicture(msg.State[enums.PropPicture])
+		if nil == picture {
+			continue
+		}
+
+		select {
+		case <-frames:
+		default:
+		}
+
+		frames <- picture
+	}
+}
+
+// Returns camera, if it's allowed for the user.
+// Responds with error otherwise.
+func (s *GoHomeServer) getAllowedCamera(writer http.ResponseWriter, request *http.Request) *knownDevice {
+	vars := mux.Vars(request)
+	kd := s.state.GetDevice(vars[string(urlDeviceID)])
+
+	// We don't want to allow to brute-forth device names, so returning generic error
+	if nil == kd || kd.Type != enums.DevCamera || !s.isAllowed(getContextUser(request).DeviceGet, kd.ID) {
+		respondError(writer, "Unknown device")
+		return nil
+	}
+
+	return kd
+}
+
+// Requests a fresh picture from the camera.
+func (s *GoHomeServer) requestPicture(kd *knownDevice) {
+	if !helpers.SliceContainsString(kd.Commands, enums.CmdTakePicture.String()) {
+		return
+	}
+
+	s.Logger.Debug("Requesting camera picture", common.LogSystemToken, logSystem, common.LogIDToken, kd.ID)
+	s.Settings.ServiceBus().PublishToWorker(kd.Worker,
+		bus.NewDeviceCommandMessage(kd.ID, enums.CmdTakePicture, make(map[string]interface{})))
+}
+
+// Writes a single MJPEG frame.
+func writeFrame(writer http.ResponseWriter, flusher http.Flusher, picture []byte) error {
+	_, err := fmt.Fprintf(writer, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\n\r\n",
+		mjpegBoundary, len(picture))
+	if err == nil {
+		_, err = writer.Write(append(picture, '\r', '\n'))
+	}
+
+	flusher.Flush()
+	return err
+}
+
+// Decodes base64 picture from the device state.
+func decodePicture(picture interface{}) []byte {
+	encoded, ok := picture.(string)
+	if !ok || "" == encoded {
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
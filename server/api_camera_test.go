@@ -0,0 +1,249 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gobwas/glob"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems/bus"
+	"go-home.io/x/server/systems/fanout"
+	"go-home.io/x/server/systems/security"
+)
+
+// Returns server with a single camera.
+func getCameraServer(picture string, published *[]string) *GoHomeServer {
+	mutex := sync.Mutex{}
+	s := getFakeSettings(func(name string, msg ...interface{}) {
+		if m, ok := msg[0].(*bus.DeviceCommandMessage); ok && enums.CmdTakePicture == m.Command {
+			mutex.Lock()
+			*published = append(*published, name+":"+m.DeviceID)
+			mutex.Unlock()
+		}
+	}, nil, nil)
+
+	state := newServerState(s)
+	state.KnownDevices = map[string]*knownDevice{
+		"cam": {ID: "cam", Type: enums.DevCamera, Commands: []string{enums.CmdTakePicture.String()},
+			Worker: "1", State: map[string]interface{}{}},
+		"dev1": {ID: "dev1", Commands: []string{enums.CmdOn.String()}, Worker: "1"},
+	}
+
+	if "" != picture {
+		state.KnownDevices["cam"].State[enums.PropPicture.String()] = picture
+	}
+
+	return &GoHomeServer{
+		state:    state,
+		Logger:   mocks.FakeNewLogger(nil),
+		Settings: s,
+	}
+}
+
+// Returns camera request.
+func getCameraRequest(ctx context.Context, t *testing.T, deviceID string) *http.Request {
+	req, err := http.NewRequest("GET", "/test", nil)
+	require.NoError(t, err, "setup failed")
+	return mux.SetURLVars(req.WithContext(ctx), map[string]string{string(urlDeviceID): deviceID})
+}
+
+// Tests camera snapshot API.
+func TestCameraSnapshot(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	published := make([]string, 0)
+	srv := getCameraServer(base64.StdEncoding.EncodeToString([]byte("picture")), &published)
+
+	input := map[string]int{
+		"cam":  http.StatusOK,
+		"dev1": http.StatusInternalServerError,
+		"dev2": http.StatusInternalServerError,
+	}
+
+	for k, v := range input {
+		r := httptest.NewRecorder()
+		http.HandlerFunc(srv.getCameraSnapshot).ServeHTTP(r, getCameraRequest(context.Background(), t, k))
+		require.Equal(t, v, r.Code, "response code %s", k)
+
+		if http.StatusOK == v {
+			assert.Equal(t, "image/jpeg", r.Header().Get("Content-Type"), "content type")
+			assert.Equal(t, "picture", r.Body.String(), "picture")
+		}
+	}
+
+	assert.Equal(t, []string{"1:cam"}, published, "take picture")
+}
+
+// Tests waiting for the first camera picture.
+func TestCameraSnapshotWait(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	published := make([]string, 0)
+	srv := getCameraServer("", &published)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		srv.Settings.FanOut().ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+			ID:    "dev1",
+			State: map[enums.Property]interface{}{enums.PropOn: true},
+		}
+		srv.Settings.FanOut().ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+			ID:    "cam",
+			State: map[enums.Property]interface{}{enums.PropPicture: base64.StdEncoding.EncodeToString([]byte("new"))},
+		}
+	}()
+
+	r := httptest.NewRecorder()
+	http.HandlerFunc(srv.getCameraSnapshot).ServeHTTP(r, getCameraRequest(context.Background(), t, "cam"))
+	require.Equal(t, http.StatusOK, r.Code, "response code")
+	assert.Equal(t, "new", r.Body.String(), "picture")
+}
+
+// Tests camera MJPEG stream.
+func TestCameraStream(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	published := make([]string, 0)
+	srv := getCameraServer(base64.StdEncoding.EncodeToString([]byte("first")), &published)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := httptest.NewRecorder()
+	done := make(chan bool)
+	go func() {
+		http.HandlerFunc(srv.getCameraStream).ServeHTTP(r, getCameraRequest(ctx, t, "cam"))
+		done <- true
+	}()
+
+	srv.Settings.FanOut().ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:    "dev1",
+		State: map[enums.Property]interface{}{enums.PropPicture: base64.StdEncoding.EncodeToString([]byte("wrong"))},
+	}
+	srv.Settings.FanOut().ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:    "cam",
+		State: map[enums.Property]interface{}{enums.PropPicture: base64.StdEncoding.EncodeToString([]byte("second"))},
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, http.StatusOK, r.Code, "response code")
+	assert.Equal(t, "multipart/x-mixed-replace; boundary="+mjpegBoundary, r.Header().Get("Content-Type"), "type")
+	body := r.Body.String()
+	assert.Equal(t, 2, strings.Count(body, "--"+mjpegBoundary), "frames")
+	assert.True(t, strings.Index(body, "first") < strings.Index(body, "second"), "order")
+	assert.False(t, strings.Contains(body, "wrong"), "other device")
+	assert.Equal(t, []string{"1:cam"}, published, "take picture")
+}
+
+// Settings with the real fan-out provider.
+type fanOutSettings struct {
+	providers.ISettingsProvider
+	fanOut providers.IInternalFanOutProvider
+}
+
+func (s *fanOutSettings) FanOut() providers.IInternalFanOutProvider {
+	return s.fanOut
+}
+
+// Response writer which blocks until released.
+type blockingWriter struct {
+	*httptest.ResponseRecorder
+	release chan bool
+}
+
+func (w *blockingWriter) Write(data []byte) (int, error) {
+	<-w.release
+	return len(data), nil
+}
+
+// Tests that slow stream clients don't block updates fan-out.
+func TestCameraStreamSlowClient(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	published := make([]string, 0)
+	srv := getCameraServer("", &published)
+	srv.Settings = &fanOutSettings{ISettingsProvider: srv.Settings, fanOut: fanout.NewFanOut()}
+	_, other := srv.Settings.FanOut().SubscribeDeviceUpdates()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &blockingWriter{ResponseRecorder: httptest.NewRecorder(), release: make(chan bool)}
+	done := make(chan bool)
+	go func() {
+		http.HandlerFunc(srv.getCameraStream).ServeHTTP(w, getCameraRequest(ctx, t, "cam"))
+		done <- true
+	}()
+
+	total := 50
+	go func() {
+		for ii := 0; ii < total; ii++ {
+			srv.Settings.FanOut().ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+				ID:    "cam",
+				State: map[enums.Property]interface{}{enums.PropPicture: base64.StdEncoding.EncodeToString([]byte("pic"))},
+			}
+		}
+	}()
+
+	timeout := time.After(5 * time.Second)
+	for ii := 0; ii < total; ii++ {
+		select {
+		case <-other:
+		case <-timeout:
+			require.Fail(t, "fan-out is blocked")
+		}
+	}
+
+	cancel()
+	close(w.release)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "stream is not closed")
+	}
+}
+
+// Tests camera API without get permissions.
+func TestCameraForbidden(t *testing.T) {
+	monkey.Patch(getContextUser, func(_ *http.Request) providers.IAuthenticatedUser {
+		return &security.AuthenticatedUser{
+			Username: "test",
+			Rules: map[providers.SecSystem][]*providers.BakedRule{
+				providers.SecSystemDevice: {
+					{
+						Get:       true,
+						Command:   true,
+						Resources: []glob.Glob{compileRegexp("dev*")},
+					},
+				},
+			},
+		}
+	})
+	defer monkey.UnpatchAll()
+
+	published := make([]string, 0)
+	srv := getCameraServer(base64.StdEncoding.EncodeToString([]byte("picture")), &published)
+	for _, v := range []http.HandlerFunc{srv.getCameraSnapshot, srv.getCameraStream} {
+		r := httptest.NewRecorder()
+		v.ServeHTTP(r, getCameraRequest(context.Background(), t, "cam"))
+		assert.Equal(t, http.StatusInternalServerError, r.Code, "response code")
+		assert.NotContains(t, r.Body.String(), "picture", "picture")
+	}
+
+	assert.Equal(t, 0, len(published), "take picture")
+}
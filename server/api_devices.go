@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"go-home.io/x/server/plugins/device"
 	"go-home.io/x/server/plugins/device/enums"
 )
 
@@ -32,14 +33,22 @@ type currentState struct {
 
 // Known devices, received from workers.
 type knownDevice struct {
-	ID         string                 `json:"id"`
-	Name       string                 `json:"name"`
-	Worker     string                 `json:"worker"`
-	Type       enums.DeviceType       `json:"type"`
-	State      map[string]interface{} `json:"state"`
-	LastSeen   int64                  `json:"last_seen"`
-	Commands   []string               `json:"commands"`
-	IsReadOnly bool                   `json:"read_only"`
+	ID           string                 `json:"id"`
+	Name         string                 `json:"name"`
+	Worker       string                 `json:"worker"`
+	Type         enums.DeviceType       `json:"type"`
+	State        map[string]interface{} `json:"state"`
+	LastSeen     int64                  `json:"last_seen"`
+	Commands     []string               `json:"commands"`
+	IsReadOnly   bool                   `json:"read_only"`
+	Available    bool                   `json:"available"`
+	Capabilities *device.Capabilities   `json:"capabilities,omitempty"`
+	Icon         string                 `json:"icon,omitempty"`
+	Aliases      []string               `json:"aliases,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Hidden       bool                   `json:"hidden"`
+	Removed      bool                   `json:"removed,omitempty"`
+	Version      uint64                 `json:"-"`
 }
 
 // Returns all devices available for the user.
@@ -88,7 +97,7 @@ func (s *GoHomeServer) getStateHistory(writer http.ResponseWriter, request *http
 		return
 	}
 
-	if !user.DeviceHistory(kd.ID) {
+	if !s.isAllowed(user.DeviceHistory, kd.ID) {
 		respondForbidden(writer)
 		return
 	}
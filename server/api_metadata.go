@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/providers"
+)
+
+// Returns metadata of all devices available for the user.
+func (s *GoHomeServer) getMetadata(writer http.ResponseWriter, request *http.Request) {
+	respond(writer, s.commandGetMetadata(getContextUser(request)))
+}
+
+// Updates device metadata.
+func (s *GoHomeServer) setMetadata(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	b, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		respondError(writer, "Failed to read body")
+		return
+	}
+
+	respondOkError(writer, s.commandSetMetadata(getContextUser(request), vars[string(urlDeviceID)], b))
+}
+
+// Removes device metadata.
+func (s *GoHomeServer) deleteMetadata(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	respondOkError(writer, s.commandSetMetadata(getContextUser(request), vars[string(urlDeviceID)], nil))
+}
+
+// Returns metadata of all allowed for the user devices.
+func (s *GoHomeServer) commandGetMetadata(user providers.IAuthenticatedUser) map[string]*providers.DeviceMetadata {
+	response := make(map[string]*providers.DeviceMetadata)
+	for k, v := range s.Settings.Metadata().All() {
+		if s.isAllowed(user.DeviceGet, k) {
+			response[k] = v
+		}
+	}
+
+	return response
+}
+
+// Updates device metadata if it's allowed for the user.
+// Empty data removes metadata.
+func (s *GoHomeServer) commandSetMetadata(user providers.IAuthenticatedUser, deviceID string, data []byte) error {
+	knownDevice := s.state.GetDevice(deviceID)
+	if nil == knownDevice || !s.isAllowed(user.DeviceCommand, knownDevice.ID) {
+		s.Logger.Warn("User can't update metadata of this device", common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID, common.LogUserNameToken, user.Name())
+		return &ErrUnknownDevice{ID: deviceID}
+	}
+
+	metadata := &providers.DeviceMetadata{}
+	if len(data) > 0 {
+		err := json.Unmarshal(data, metadata)
+		if err != nil {
+			s.Logger.Error("Failed to unmarshal metadata", err, common.LogSystemToken, logSystem)
+			return &ErrBadRequest{}
+		}
+	}
+
+	s.Logger.Info("Updating device metadata", common.LogSystemToken, logSystem,
+		common.LogIDToken, deviceID, common.LogUserNameToken, user.Name())
+	return s.Settings.Metadata().Set(knownDevice.ID, metadata)
+}
+
+// Merges user-defined metadata into the device.
+func applyMetadata(d *knownDevice, m *providers.DeviceMetadata) {
+	if nil == m {
+		return
+	}
+
+	if "" != m.Name {
+		d.Name = m.Name
+	}
+
+	d.Icon = m.Icon
+	d.Aliases = m.Aliases
+	d.Tags = m.Tags
+	d.Hidden = m.Hidden
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/gobwas/glob"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+)
+
+// Tests metadata update API.
+func TestSetMetadataAPI(t *testing.T) {
+	input := map[string]int{
+		"dev1": http.StatusOK,
+		"dev2": http.StatusInternalServerError,
+	}
+
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	srv := getServer()
+	for k, v := range input {
+		req, err := http.NewRequest("POST", "/test",
+			strings.NewReader(`{"name": "Kitchen light", "icon": "bulb", "tags": ["kitchen"], "hidden": true}`))
+		require.NoError(t, err, "setup failed %s", k)
+		req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): k})
+
+		r := httptest.NewRecorder()
+		http.HandlerFunc(srv.setMetadata).ServeHTTP(r, req)
+		assert.Equal(t, v, r.Code, "response code %s", k)
+	}
+
+	req, err := http.NewRequest("GET", "/test", nil)
+	require.NoError(t, err, "setup failed")
+	r := httptest.NewRecorder()
+	http.HandlerFunc(srv.getMetadata).ServeHTTP(r, req)
+
+	data := make(map[string]*providers.DeviceMetadata)
+	require.NoError(t, json.Unmarshal(r.Body.Bytes(), &data), "unmarshal")
+	require.Equal(t, 1, len(data), "metadata")
+	assert.Equal(t, "Kitchen light", data["dev1"].Name, "name")
+
+	req, err = http.NewRequest("DELETE", "/test", nil)
+	require.NoError(t, err, "setup failed")
+	req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): "dev1"})
+	r = httptest.NewRecorder()
+	http.HandlerFunc(srv.deleteMetadata).ServeHTTP(r, req)
+	assert.Equal(t, http.StatusOK, r.Code, "delete")
+	assert.Nil(t, srv.Settings.Metadata().Get("dev1"), "deleted")
+}
+
+// Tests metadata merge into devices and locations.
+func TestDevicesMetadata(t *testing.T) {
+	numCalled := 0
+	srv := getServer()
+	srv.Settings = getFakeSettings(func(_ string, _ ...interface{}) {
+		numCalled++
+	}, nil, nil)
+
+	user := getFakeRootUser(nil)
+	err := srv.commandSetMetadata(user, "dev1", []byte(`{"name": "Lamp", "aliases": ["lamp"], "tags": ["kitchen"]}`))
+	require.NoError(t, err, "set dev1")
+	err = srv.commandSetMetadata(user, "device", []byte(`{"hidden": true}`))
+	require.NoError(t, err, "set device")
+	err = srv.commandSetMetadata(user, "unknown", []byte(`{"hidden": true}`))
+	assert.Error(t, err, "unknown")
+	err = srv.commandSetMetadata(user, "dev1", []byte(`{"hidden": `))
+	assert.Error(t, err, "wrong json")
+
+	for _, v := range srv.commandGetAllDevices(user) {
+		switch v.ID {
+		case "dev1":
+			assert.Equal(t, "Lamp", v.Name, "name")
+			assert.Equal(t, []string{"lamp"}, v.Aliases, "aliases")
+			assert.Equal(t, []string{"kitchen"}, v.Tags, "tags")
+			assert.False(t, v.Hidden, "dev1 hidden")
+		case "device":
+			assert.True(t, v.Hidden, "device hidden")
+		}
+	}
+
+	locations := srv.commandGetAllLocations(user)
+	require.Equal(t, 1, len(locations), "locations")
+	assert.NotContains(t, locations[0].Devices, "device", "hidden device")
+
+	srv.InternalCommandInvokeDeviceCommand(glob.MustCompile("tag:kitchen"), enums.CmdOn, nil)
+	assert.Equal(t, 1, numCalled, "tag")
+	srv.InternalCommandInvokeDeviceCommand(glob.MustCompile("lamp"), enums.CmdOn, nil)
+	assert.Equal(t, 2, numCalled, "alias")
+}
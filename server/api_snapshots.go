@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Returns list of the camera snapshots.
+func (s *GoHomeServer) getSnapshots(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	deviceID := vars[string(urlDeviceID)]
+	if !s.isAllowed(getContextUser(request).DeviceHistory, deviceID) {
+		respondForbidden(writer)
+		return
+	}
+
+	respond(writer, s.Settings.Snapshots().List(deviceID))
+}
+
+// Returns a single camera snapshot picture.
+//noinspection GoUnhandledErrorResult
+func (s *GoHomeServer) getSnapshot(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	deviceID := vars[string(urlDeviceID)]
+	if !s.isAllowed(getContextUser(request).DeviceHistory, deviceID) {
+		respondForbidden(writer)
+		return
+	}
+
+	data, err := s.Settings.Snapshots().Get(deviceID, vars[string(urlSnapshotID)])
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "image/jpeg")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(data) // nolint: gosec
+}
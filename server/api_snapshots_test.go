@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gobwas/glob"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems/bus"
+	"go-home.io/x/server/systems/security"
+)
+
+// Tests snapshots API.
+func TestSnapshotsAPI(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	srv := getServer()
+	require.NoError(t, srv.Settings.Snapshots().Add("cam", []byte("picture")), "add")
+
+	req, err := http.NewRequest("GET", "/test", nil)
+	require.NoError(t, err, "setup failed")
+	req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): "cam"})
+	r := httptest.NewRecorder()
+	http.HandlerFunc(srv.getSnapshots).ServeHTTP(r, req)
+	require.Equal(t, http.StatusOK, r.Code, "list")
+
+	list := make([]*providers.Snapshot, 0)
+	require.NoError(t, json.Unmarshal(r.Body.Bytes(), &list), "unmarshal")
+	require.Equal(t, 1, len(list), "snapshots")
+
+	input := []struct {
+		id   string
+		code int
+	}{
+		{id: "wrong", code: http.StatusNotFound},
+		{id: list[0].ID, code: http.StatusOK},
+	}
+
+	for _, v := range input {
+		req, err = http.NewRequest("GET", "/test", nil)
+		require.NoError(t, err, "setup failed %s", v.id)
+		req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): "cam", string(urlSnapshotID): v.id})
+		r = httptest.NewRecorder()
+		http.HandlerFunc(srv.getSnapshot).ServeHTTP(r, req)
+		assert.Equal(t, v.code, r.Code, "response code %s", v.id)
+	}
+
+	assert.Equal(t, "image/jpeg", r.Header().Get("Content-Type"), "content type")
+	assert.Equal(t, "picture", r.Body.String(), "picture")
+}
+
+// Tests snapshots API without history permissions.
+func TestSnapshotsAPIForbidden(t *testing.T) {
+	monkey.Patch(getContextUser, func(_ *http.Request) providers.IAuthenticatedUser {
+		return &security.AuthenticatedUser{
+			Username: "test",
+			Rules: map[providers.SecSystem][]*providers.BakedRule{
+				providers.SecSystemDevice: {
+					{
+						Get:       true,
+						Resources: []glob.Glob{compileRegexp("*")},
+					},
+				},
+			},
+		}
+	})
+	defer monkey.UnpatchAll()
+
+	srv := getServer()
+	for _, v := range []http.HandlerFunc{srv.getSnapshots, srv.getSnapshot} {
+		req, err := http.NewRequest("GET", "/test", nil)
+		require.NoError(t, err, "setup failed")
+		req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): "cam", string(urlSnapshotID): "0"})
+		r := httptest.NewRecorder()
+		v.ServeHTTP(r, req)
+		assert.Equal(t, http.StatusForbidden, r.Code, "response code")
+	}
+}
+
+// Tests that camera pictures are archived.
+func TestSnapshotsArchive(t *testing.T) {
+	s := getFakeSettings(nil, nil, nil)
+	state := newServerState(s)
+	updates := s.FanOut().ChannelInDeviceUpdates()
+	picture := base64.StdEncoding.EncodeToString([]byte("picture"))
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "cam", WorkerID: "w", DeviceType: enums.DevCamera,
+		Version: 1, State: map[string]interface{}{"picture": picture}})
+	<-updates
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "cam", WorkerID: "w", DeviceType: enums.DevCamera,
+		Version: 2, State: map[string]interface{}{"picture": picture}})
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "sensor", WorkerID: "w", DeviceType: enums.DevSensor,
+		Version: 1, State: map[string]interface{}{"picture": picture}})
+	<-updates
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 1, len(s.Snapshots().List("cam")), "camera")
+	assert.Equal(t, 0, len(s.Snapshots().List("sensor")), "sensor")
+	data, err := s.Snapshots().Get("cam", s.Snapshots().List("cam")[0].ID)
+	require.NoError(t, err, "get")
+	assert.Equal(t, "picture", string(data), "data")
+}
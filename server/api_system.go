@@ -25,12 +25,18 @@ func (s *GoHomeServer) getWorkers(writer http.ResponseWriter, request *http.Requ
 		return
 	}
 
-	workers := s.state.GetWorkers()
-	workers = append(workers, &knownWorker{
+	master := &knownWorker{
 		ID:         "master",
 		LastSeen:   utils.TimeNow(),
 		MaxDevices: 0,
-	})
+	}
+
+	if nil != s.busPool {
+		master.BusQueue = s.busPool.Stats()
+	}
+
+	workers := s.state.GetWorkers()
+	workers = append(workers, master)
 
 	sort.Slice(workers, func(i, j int) bool {
 		return workers[i].ID < workers[j].ID
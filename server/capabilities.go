@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"math"
+
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+)
+
+const (
+	// Command argument validated against capabilities.
+	capabilityArgValue = "value"
+	// Tolerance used for step validation.
+	capabilityStepTolerance = 1e-6
+)
+
+// Validates command argument against device capabilities.
+// Commands without reported capabilities are not validated.
+func validateCommandArgs(caps *device.Capabilities, cmd enums.Command, data map[string]interface{}) error {
+	if nil == caps {
+		return nil
+	}
+
+	c, ok := caps.Commands[cmd]
+	if !ok || nil == c {
+		return nil
+	}
+
+	value, ok := data[capabilityArgValue]
+	if !ok {
+		return nil
+	}
+
+	if len(c.Options) > 0 {
+		if !helpers.SliceContainsString(c.Options, fmt.Sprint(value)) {
+			return &ErrInvalidArgument{Name: cmd.String()}
+		}
+
+		return nil
+	}
+
+	if !c.HasRange() && c.Step <= 0 {
+		return nil
+	}
+
+	num, ok := value.(float64)
+	if !ok {
+		return &ErrInvalidArgument{Name: cmd.String()}
+	}
+
+	if c.HasRange() && (num < c.Min || num > c.Max) {
+		return &ErrInvalidArgument{Name: cmd.String()}
+	}
+
+	if c.Step > 0 {
+		steps := (num - c.Min) / c.Step
+		if math.Abs(steps-math.Round(steps)) > capabilityStepTolerance {
+			return &ErrInvalidArgument{Name: cmd.String()}
+		}
+	}
+
+	return nil
+}
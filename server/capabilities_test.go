@@ -0,0 +1,55 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Tests command arguments validation.
+func TestValidateCommandArgs(t *testing.T) {
+	caps := &device.Capabilities{
+		Commands: map[enums.Command]*device.Capability{
+			enums.CmdSetBrightness:  {Min: 0, Max: 100},
+			enums.CmdSetFanSpeed:    {Min: 0, Max: 100, Step: 25},
+			enums.CmdSetMode:        {Options: []string{"heat", "cool"}},
+			enums.CmdSetTemperature: {Step: 0.5},
+		},
+	}
+
+	data := []struct {
+		cmd   enums.Command
+		value interface{}
+		valid bool
+	}{
+		{enums.CmdSetBrightness, 50.0, true},
+		{enums.CmdSetBrightness, 0.0, true},
+		{enums.CmdSetBrightness, 101.0, false},
+		{enums.CmdSetBrightness, "50", false},
+		{enums.CmdSetFanSpeed, 75.0, true},
+		{enums.CmdSetFanSpeed, 60.0, false},
+		{enums.CmdSetMode, "cool", true},
+		{enums.CmdSetMode, "dry", false},
+		{enums.CmdSetTemperature, 21.5, true},
+		{enums.CmdSetTemperature, 21.3, false},
+		{enums.CmdOn, nil, true},
+	}
+
+	for _, v := range data {
+		args := map[string]interface{}{}
+		if nil != v.value {
+			args["value"] = v.value
+		}
+
+		err := validateCommandArgs(caps, v.cmd, args)
+		if v.valid {
+			assert.NoError(t, err, "%s %v", v.cmd, v.value)
+		} else {
+			assert.IsType(t, &ErrInvalidArgument{}, err, "%s %v", v.cmd, v.value)
+		}
+	}
+
+	assert.NoError(t, validateCommandArgs(nil, enums.CmdSetBrightness, map[string]interface{}{"value": 500.0}))
+}
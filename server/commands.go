@@ -19,7 +19,7 @@ const (
 )
 
 // InternalCommandInvokeDeviceCommand invokes devices operations.
-// This command is used strictly internally.
+// This command is used strictly internally, so locks' PIN and confirmation are not required.
 func (s *GoHomeServer) InternalCommandInvokeDeviceCommand(
 	deviceRegexp glob.Glob, cmd enums.Command, data map[string]interface{}) {
 	if nil == data {
@@ -27,7 +27,7 @@ func (s *GoHomeServer) InternalCommandInvokeDeviceCommand(
 	}
 
 	for _, v := range s.state.GetAllDevices() {
-		if !deviceRegexp.Match(v.ID) {
+		if !s.Settings.Metadata().Match(deviceRegexp, v.ID) {
 			continue
 		}
 
@@ -66,7 +66,7 @@ func (s *GoHomeServer) commandInvokeDeviceCommand(user providers.IAuthenticatedU
 	}
 
 	// We don't want to allow to brute-forth device names, so returning generic error
-	if !user.DeviceCommand(knownDevice.ID) {
+	if !s.isAllowed(user.DeviceCommand, knownDevice.ID) {
 		s.Logger.Warn("User doesn't have access to this device", common.LogSystemToken, logSystem,
 			common.LogIDToken, deviceID, common.LogUserNameToken, user.Name())
 		return &ErrUnknownDevice{ID: deviceID}
@@ -105,6 +105,28 @@ func (s *GoHomeServer) commandInvokeDeviceCommand(user providers.IAuthenticatedU
 		return s.commandGroupCommand(user, knownDevice.ID, command, inputData)
 	}
 
+	err = validateCommandArgs(knownDevice.Capabilities, command, inputData)
+	if err != nil {
+		s.Logger.Warn("Received command arguments are not allowed", common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID, common.LogDeviceCommandToken, cmdName,
+			common.LogUserNameToken, user.Name(), common.LogErrorToken, err.Error())
+		return err
+	}
+
+	if knownDevice.Type == enums.DevLock {
+		err = s.locks.validate(user, knownDevice.ID, command, inputData)
+		if _, ok := err.(*ErrConfirmationRequired); ok {
+			return err
+		}
+
+		if err != nil {
+			s.Logger.Warn("Lock command was not validated", common.LogSystemToken, logSystem,
+				common.LogIDToken, deviceID, common.LogDeviceCommandToken, cmdName,
+				common.LogUserNameToken, user.Name(), common.LogErrorToken, err.Error())
+			return err
+		}
+	}
+
 	s.Logger.Debug("Invoking device operation", common.LogSystemToken, logSystem,
 		common.LogIDToken, deviceID, common.LogDeviceCommandToken, cmdName,
 		common.LogUserNameToken, user.Name())
@@ -113,7 +135,7 @@ func (s *GoHomeServer) commandInvokeDeviceCommand(user providers.IAuthenticatedU
 	return nil
 }
 
-// Invokes group command
+// Invokes group command if all group members accept it.
 func (s *GoHomeServer) commandGroupCommand(user providers.IAuthenticatedUser,
 	groupID string, cmd enums.Command, data map[string]interface{}) error {
 	g, ok := s.groups[groupID]
@@ -124,10 +146,41 @@ func (s *GoHomeServer) commandGroupCommand(user providers.IAuthenticatedUser,
 		return &ErrUnknownGroup{Name: groupID}
 	}
 
+	err := s.validateGroupCommand(g, cmd, data)
+	if err != nil {
+		s.Logger.Warn("Group command was not validated", common.LogSystemToken, logSystem,
+			common.LogIDToken, groupID, common.LogDeviceCommandToken, cmd.String(),
+			common.LogUserNameToken, user.Name(), common.LogErrorToken, err.Error())
+		return err
+	}
+
 	g.InvokeCommand(cmd, data)
 	return nil
 }
 
+// Validates group command against every group member which supports it.
+// Locks with PIN or confirmation can't be controlled through a group.
+func (s *GoHomeServer) validateGroupCommand(g providers.IGroupProvider,
+	cmd enums.Command, data map[string]interface{}) error {
+	for _, v := range g.Devices() {
+		d := s.state.GetDevice(v)
+		if nil == d || !helpers.SliceContainsString(d.Commands, cmd.String()) {
+			continue
+		}
+
+		if d.Type == enums.DevLock && s.locks.isProtected(d.ID) {
+			return &ErrLockInGroup{ID: d.ID}
+		}
+
+		err := validateCommandArgs(d.Capabilities, cmd, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Returns all allowed for the user devices.
 func (s *GoHomeServer) commandGetAllDevices(user providers.IAuthenticatedUser) []*knownDevice {
 	allowedDevices := make([]*knownDevice, 0)
@@ -139,17 +192,20 @@ func (s *GoHomeServer) commandGetAllDevices(user providers.IAuthenticatedUser) [
 			worker = ""
 		}
 
-		if user.DeviceGet(v.ID) {
+		if s.isAllowed(user.DeviceGet, v.ID) {
 			d := &knownDevice{
-				ID:         v.ID,
-				Type:       v.Type,
-				State:      v.State,
-				Name:       v.Name,
-				Worker:     worker,
-				Commands:   v.Commands,
-				LastSeen:   v.LastSeen,
-				IsReadOnly: !user.DeviceCommand(v.ID),
+				ID:           v.ID,
+				Type:         v.Type,
+				State:        v.State,
+				Name:         v.Name,
+				Worker:       worker,
+				Commands:     v.Commands,
+				LastSeen:     v.LastSeen,
+				IsReadOnly:   !s.isAllowed(user.DeviceCommand, v.ID),
+				Available:    v.Available,
+				Capabilities: v.Capabilities,
 			}
+			applyMetadata(d, s.Settings.Metadata().Get(v.ID))
 			allowedDevices = append(allowedDevices, d)
 		}
 	}
@@ -179,7 +235,7 @@ func (s *GoHomeServer) commandGetAllGroups(user providers.IAuthenticatedUser) []
 
 		for _, dev := range g.Devices() {
 			d := s.state.GetDevice(dev)
-			if nil == d || !user.DeviceGet(v.ID) {
+			if nil == d || !s.isAllowed(user.DeviceGet, v.ID) {
 				continue
 			}
 
@@ -216,7 +272,7 @@ func (s *GoHomeServer) commandGetAllLocations(user providers.IAuthenticatedUser)
 
 		for _, dev := range v.Devices() {
 			d := s.state.GetDevice(dev)
-			if nil == d || !user.DeviceGet(d.ID) {
+			if nil == d || !s.isAllowed(user.DeviceGet, d.ID) {
 				continue
 			}
 
@@ -236,7 +292,7 @@ func (s *GoHomeServer) commandGetAllLocations(user providers.IAuthenticatedUser)
 
 	devicesLeft := make([]string, 0)
 	for _, v := range devices {
-		if helpers.SliceContainsString(devicesProcessed, v.ID) || groupsHasDevice(groups, v.ID) {
+		if v.Hidden || helpers.SliceContainsString(devicesProcessed, v.ID) || groupsHasDevice(groups, v.ID) {
 			continue
 		}
 
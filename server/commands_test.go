@@ -7,8 +7,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/device"
 	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems/bus"
 	"go-home.io/x/server/systems/security"
 )
 
@@ -250,6 +252,144 @@ func TestWrongCommand(t *testing.T) {
 	}
 }
 
+// Tests lock commands PIN and confirmation validation.
+func TestLockCommands(t *testing.T) {
+	var published map[string]interface{}
+	s := getFakeSettings(func(name string, msg ...interface{}) {
+		published = msg[0].(*bus.DeviceCommandMessage).Payload
+	}, nil, nil)
+	state := newServerState(s)
+	state.KnownDevices = map[string]*knownDevice{
+		"front.lock.door": {ID: "front.lock.door", Type: enums.DevLock, Worker: "1",
+			Commands: []string{enums.CmdLock.String(), enums.CmdUnlock.String()}},
+		"back.lock.door": {ID: "back.lock.door", Type: enums.DevLock, Worker: "1",
+			Commands: []string{enums.CmdLock.String(), enums.CmdUnlock.String()}},
+	}
+	srv := &GoHomeServer{
+		state:    state,
+		Logger:   mocks.FakeNewLogger(nil),
+		Settings: s,
+		locks: newLocksSecurity(mocks.FakeNewLogger(nil), []*providers.RawDevice{
+			{Name: "Front", StrConfig: "lockPin: 1234\nlockConfirmation: true"},
+			{Name: "Back", StrConfig: "lockPin: 1234"},
+		}),
+	}
+
+	user := &security.AuthenticatedUser{
+		Username: "usr1",
+		Rules: map[providers.SecSystem][]*providers.BakedRule{
+			providers.SecSystemDevice: {
+				{
+					Command:   true,
+					Resources: []glob.Glob{compileRegexp("*")},
+				},
+			},
+		},
+	}
+
+	err := srv.commandInvokeDeviceCommand(user, "back.lock.door", "unlock", []byte(`{"pin": "1111"}`))
+	assert.IsType(t, &ErrInvalidPin{}, err, "wrong pin")
+	assert.Nil(t, published, "wrong pin published")
+
+	err = srv.commandInvokeDeviceCommand(user, "back.lock.door", "unlock", []byte(`{"pin": 1234}`))
+	require.NoError(t, err, "correct pin")
+	require.NotNil(t, published, "correct pin not published")
+	assert.Equal(t, 0, len(published), "pin was not removed")
+
+	published = nil
+	err = srv.commandInvokeDeviceCommand(user, "front.lock.door", "unlock", []byte(`{"pin": "1234"}`))
+	require.IsType(t, &ErrConfirmationRequired{}, err, "confirmation")
+	assert.Nil(t, published, "not confirmed published")
+	token := err.(*ErrConfirmationRequired).Token
+
+	err = srv.commandInvokeDeviceCommand(user, "front.lock.door", "lock", []byte(`{"confirm": "`+token+`"}`))
+	assert.IsType(t, &ErrInvalidConfirmation{}, err, "wrong command")
+
+	err = srv.commandInvokeDeviceCommand(user, "front.lock.door", "unlock", []byte(`{"confirm": "`+token+`"}`))
+	assert.IsType(t, &ErrInvalidConfirmation{}, err, "used token")
+	assert.Nil(t, published, "wrong confirmation published")
+
+	err = srv.commandInvokeDeviceCommand(user, "front.lock.door", "unlock", []byte(`{"pin": "1234"}`))
+	token = err.(*ErrConfirmationRequired).Token
+	err = srv.commandInvokeDeviceCommand(user, "front.lock.door", "unlock", []byte(`{"confirm": "`+token+`"}`))
+	require.NoError(t, err, "confirmed")
+	assert.NotNil(t, published, "confirmed not published")
+}
+
+// Tests group commands validation against group members.
+func TestGroupCommandValidation(t *testing.T) {
+	published := false
+	s := getFakeSettings(func(name string, msg ...interface{}) {
+		published = true
+	}, nil, nil)
+	state := newServerState(s)
+	state.KnownDevices = map[string]*knownDevice{
+		"front.lock.door": {ID: "front.lock.door", Type: enums.DevLock, Worker: "1",
+			Commands: []string{enums.CmdLock.String(), enums.CmdUnlock.String()}},
+		"hall.light.main": {ID: "hall.light.main", Type: enums.DevLight, Worker: "1",
+			Commands: []string{enums.CmdOn.String(), enums.CmdSetBrightness.String()},
+			Capabilities: &device.Capabilities{Commands: map[enums.Command]*device.Capability{
+				enums.CmdSetBrightness: {Min: 0, Max: 50},
+			}}},
+		"back.lock.door": {ID: "back.lock.door", Type: enums.DevLock, Worker: "1",
+			Commands: []string{enums.CmdLock.String(), enums.CmdUnlock.String()}},
+		"g1": {ID: "g1", Type: enums.DevGroup, Worker: "1",
+			Commands: []string{enums.CmdUnlock.String(), enums.CmdOn.String(), enums.CmdSetBrightness.String()}},
+		"g2": {ID: "g2", Type: enums.DevGroup, Worker: "1",
+			Commands: []string{enums.CmdLock.String(), enums.CmdUnlock.String()}},
+	}
+
+	groupCalled := false
+	unprotectedCalled := false
+	srv := &GoHomeServer{
+		state:    state,
+		Logger:   mocks.FakeNewLogger(nil),
+		Settings: s,
+		locks: newLocksSecurity(mocks.FakeNewLogger(nil), []*providers.RawDevice{
+			{Name: "Front", StrConfig: "lockPin: 1234"},
+		}),
+		groups: map[string]providers.IGroupProvider{
+			"g1": mocks.FakeNewGroupProvider("g1", []string{"front.lock.door", "hall.light.main"}, func() {
+				groupCalled = true
+			}),
+			"g2": mocks.FakeNewGroupProvider("g2", []string{"back.lock.door"}, func() {
+				unprotectedCalled = true
+			}),
+		},
+	}
+
+	user := &security.AuthenticatedUser{
+		Username: "usr1",
+		Rules: map[providers.SecSystem][]*providers.BakedRule{
+			providers.SecSystemDevice: {
+				{
+					Command:   true,
+					Resources: []glob.Glob{compileRegexp("*")},
+				},
+			},
+		},
+	}
+
+	err := srv.commandInvokeDeviceCommand(user, "g1", "unlock", nil)
+	assert.IsType(t, &ErrLockInGroup{}, err, "unlock")
+	err = srv.commandInvokeDeviceCommand(user, "g1", "unlock", []byte(`{"pin": "1234"}`))
+	assert.IsType(t, &ErrLockInGroup{}, err, "unlock with pin")
+	assert.False(t, groupCalled, "lock group called")
+
+	err = srv.commandInvokeDeviceCommand(user, "g1", "set-brightness", []byte("70"))
+	assert.IsType(t, &ErrInvalidArgument{}, err, "capabilities")
+	assert.False(t, groupCalled, "wrong argument group called")
+
+	err = srv.commandInvokeDeviceCommand(user, "g1", "set-brightness", []byte("30"))
+	require.NoError(t, err, "valid argument")
+	assert.True(t, groupCalled, "group not called")
+	assert.False(t, published, "published directly")
+
+	err = srv.commandInvokeDeviceCommand(user, "g2", "unlock", nil)
+	require.NoError(t, err, "unprotected lock")
+	assert.True(t, unprotectedCalled, "unprotected lock group not called")
+}
+
 // Tests correct filtration of devices.
 func TestGetAllDevices(t *testing.T) {
 	s := getFakeSettings(nil, nil, nil)
@@ -10,12 +10,25 @@ const (
 	urlDeviceID muxKeys = "deviceID"
 	// urlCommandName describes device command name URL param.
 	urlCommandName muxKeys = "commandName"
+	// urlSnapshotID describes camera snapshot ID URL param.
+	urlSnapshotID muxKeys = "snapshotID"
 	// ctxtUserName describes user in the context.
 	ctxtUserName muxKeys = "user"
 	// routeAPI describes base api prefix.
 	routeAPI = "/api/v1"
 )
 
+const (
+	// deviceResyncInterval describes minimum interval in seconds between device resync requests.
+	deviceResyncInterval = 5
+	// lockConfirmationTimeout describes time in seconds for confirming lock command.
+	lockConfirmationTimeout = 30
+	// cameraPictureTimeout describes time in seconds for waiting the first camera picture.
+	cameraPictureTimeout = 5
+	// mjpegBoundary describes boundary between MJPEG stream frames.
+	mjpegBoundary = "gohomeframe"
+)
+
 // entityStatus describes enum with entity load status.
 type entityStatus int
 
@@ -42,6 +42,16 @@ func (e *ErrUnsupportedCommand) Error() string {
 	return fmt.Sprintf("command %s is not supported", e.Name)
 }
 
+// ErrInvalidArgument defines command argument which is not allowed by device capabilities.
+type ErrInvalidArgument struct {
+	Name string
+}
+
+// Error formats output.
+func (e *ErrInvalidArgument) Error() string {
+	return fmt.Sprintf("argument of command %s is not allowed", e.Name)
+}
+
 // ErrBadRequest defines generic server error.
 type ErrBadRequest struct {
 }
@@ -50,3 +60,52 @@ type ErrBadRequest struct {
 func (e *ErrBadRequest) Error() string {
 	return "bad request"
 }
+
+// ErrInvalidPin defines missing or wrong PIN error.
+type ErrInvalidPin struct {
+}
+
+// Error formats output.
+func (e *ErrInvalidPin) Error() string {
+	return "pin is missing or invalid"
+}
+
+// ErrConfirmationRequired defines error returned when command requires confirmation.
+type ErrConfirmationRequired struct {
+	ID    string
+	Token string
+}
+
+// Error formats output.
+func (e *ErrConfirmationRequired) Error() string {
+	return fmt.Sprintf("confirmation required, token %s", e.Token)
+}
+
+// ErrInvalidConfirmation defines wrong or expired confirmation token error.
+type ErrInvalidConfirmation struct {
+}
+
+// Error formats output.
+func (e *ErrInvalidConfirmation) Error() string {
+	return "confirmation token is invalid or expired"
+}
+
+// ErrDeviceInUse defines device which can't be renamed, since it's still reported by workers.
+type ErrDeviceInUse struct {
+	ID string
+}
+
+// Error formats output.
+func (e *ErrDeviceInUse) Error() string {
+	return fmt.Sprintf("device %s is still in use", e.ID)
+}
+
+// ErrLockInGroup defines lock command sent to a group, which would bypass lock security.
+type ErrLockInGroup struct {
+	ID string
+}
+
+// Error formats output.
+func (e *ErrLockInGroup) Error() string {
+	return fmt.Sprintf("lock %s can't be controlled through a group", e.ID)
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Command argument with lock PIN.
+	lockArgPin = "pin"
+	// Command argument with confirmation token.
+	lockArgConfirm = "confirm"
+)
+
+// Lock commands security settings, loaded from device config.
+type lockSettings struct {
+	Pin          string `yaml:"lockPin"`
+	Confirmation bool   `yaml:"lockConfirmation"`
+}
+
+// Pending lock command confirmation.
+type lockConfirmation struct {
+	DeviceID string
+	Command  enums.Command
+	User     string
+	Expire   int64
+}
+
+// Lock commands validator.
+type locksSecurity struct {
+	sync.Mutex
+	settings      map[string]*lockSettings
+	confirmations map[string]*lockConfirmation
+}
+
+// Constructs lock commands validator from devices configs.
+// Settings are stored by the normalized config name, which is a prefix of the device ID.
+func newLocksSecurity(logger common.ILoggerProvider, devices []*providers.RawDevice) *locksSecurity {
+	l := &locksSecurity{
+		settings:      make(map[string]*lockSettings),
+		confirmations: make(map[string]*lockConfirmation),
+	}
+
+	for _, v := range devices {
+		s := &lockSettings{}
+		err := yaml.Unmarshal([]byte(v.StrConfig), s)
+		if err != nil {
+			logger.Warn("Failed to read lock settings", common.LogSystemToken, logSystem,
+				common.LogNameToken, v.Name)
+			continue
+		}
+
+		if "" == s.Pin && !s.Confirmation {
+			continue
+		}
+
+		l.settings[utils.NormalizeDeviceName(v.Name)] = s
+	}
+
+	return l
+}
+
+// Checks whether lock requires PIN or confirmation.
+func (l *locksSecurity) isProtected(deviceID string) bool {
+	if nil == l {
+		return false
+	}
+
+	_, ok := l.settings[strings.Split(deviceID, ".")[0]]
+	return ok
+}
+
+// Validates lock command and removes security arguments.
+// PIN is checked first, confirmation token is issued only after successful PIN validation.
+func (l *locksSecurity) validate(user providers.IAuthenticatedUser, deviceID string,
+	cmd enums.Command, data map[string]interface{}) error {
+	pin, hasPin := data[lockArgPin]
+	token, hasToken := data[lockArgConfirm]
+	delete(data, lockArgPin)
+	delete(data, lockArgConfirm)
+
+	if nil == l {
+		return nil
+	}
+
+	s, ok := l.settings[strings.Split(deviceID, ".")[0]]
+	if !ok {
+		return nil
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if hasToken && s.Confirmation {
+		return l.confirm(user, deviceID, cmd, fmt.Sprint(token))
+	}
+
+	if "" != s.Pin && (!hasPin || 1 != subtle.ConstantTimeCompare([]byte(s.Pin), []byte(fmt.Sprint(pin)))) {
+		return &ErrInvalidPin{}
+	}
+
+	if !s.Confirmation {
+		return nil
+	}
+
+	l.cleanup()
+	t := getConfirmationToken()
+	l.confirmations[t] = &lockConfirmation{
+		DeviceID: deviceID,
+		Command:  cmd,
+		User:     user.Name(),
+		Expire:   utils.TimeNow() + lockConfirmationTimeout,
+	}
+
+	return &ErrConfirmationRequired{ID: deviceID, Token: t}
+}
+
+// Validates confirmation token.
+// Token is valid only once and for the same user, device and command.
+func (l *locksSecurity) confirm(user providers.IAuthenticatedUser, deviceID string,
+	cmd enums.Command, token string) error {
+	c, ok := l.confirmations[token]
+	if !ok {
+		return &ErrInvalidConfirmation{}
+	}
+
+	delete(l.confirmations, token)
+	if c.DeviceID != deviceID || c.Command != cmd || c.User != user.Name() || c.Expire < utils.TimeNow() {
+		return &ErrInvalidConfirmation{}
+	}
+
+	return nil
+}
+
+// Removes expired confirmations.
+func (l *locksSecurity) cleanup() {
+	now := utils.TimeNow()
+	for k, v := range l.confirmations {
+		if v.Expire < now {
+			delete(l.confirmations, k)
+		}
+	}
+}
+
+// Generates a new random confirmation token.
+func getConfirmationToken() string {
+	b := make([]byte, 16)
+	rand.Read(b) // nolint: gosec, errcheck
+	return hex.EncodeToString(b)
+}
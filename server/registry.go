@@ -0,0 +1,169 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/helpers"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems/storage"
+	"go-home.io/x/server/utils"
+)
+
+const (
+	// Minimal similarity of device IDs to consider a device renamed.
+	similarDeviceThreshold = 0.75
+)
+
+// Device rename request.
+type renameRequest struct {
+	ID string `json:"id"`
+}
+
+// Renames device, migrating its history and metadata.
+func (s *GoHomeServer) renameDevice(writer http.ResponseWriter, request *http.Request) {
+	user := getContextUser(request)
+	if !user.Entities() {
+		respondForbidden(writer)
+		return
+	}
+
+	vars := mux.Vars(request)
+	b, err := ioutil.ReadAll(request.Body)
+	if err != nil {
+		respondError(writer, "Failed to read body")
+		return
+	}
+
+	respondOkError(writer, s.commandRenameDevice(user, vars[string(urlDeviceID)], b))
+}
+
+// Renames device which is not reported by workers anymore to a known device ID.
+func (s *GoHomeServer) commandRenameDevice(user providers.IAuthenticatedUser, from string, data []byte) error {
+	req := &renameRequest{}
+	err := json.Unmarshal(data, req)
+	if err != nil || "" == req.ID || from == req.ID {
+		return &ErrBadRequest{}
+	}
+
+	if nil != s.state.GetDevice(from) {
+		return &ErrDeviceInUse{ID: from}
+	}
+
+	migrated, err := s.isRenameMigrated(from, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if nil == s.state.GetDevice(req.ID) {
+		return &ErrUnknownDevice{ID: req.ID}
+	}
+
+	// Rules written for the old ID are applied to the renamed device,
+	// so only users with full access to both IDs can rename devices.
+	for _, v := range []func(string) bool{user.DeviceGet, user.DeviceCommand, user.DeviceHistory} {
+		if !v(from) || !v(req.ID) {
+			s.Logger.Warn("User doesn't have access to renamed device", common.LogSystemToken, logSystem,
+				common.LogIDToken, req.ID, "previous_id", from, common.LogUserNameToken, user.Name())
+			return &ErrUnknownDevice{ID: req.ID}
+		}
+	}
+
+	if !migrated {
+		err = s.Settings.Metadata().Rename(from, req.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.renameHistory(from, req.ID)
+	if err != nil {
+		return err
+	}
+
+	s.Logger.Info("Device was renamed", common.LogSystemToken, logSystem,
+		common.LogIDToken, req.ID, "previous_id", from, common.LogUserNameToken, user.Name())
+	return nil
+}
+
+// Checks whether device metadata was already migrated to a new ID.
+// Metadata is renamed before history, so if history migration fails, rename can be repeated.
+func (s *GoHomeServer) isRenameMigrated(from string, to string) (bool, error) {
+	m := s.Settings.Metadata().Get(from)
+	if nil != m && m.FirstSeen > 0 {
+		return false, nil
+	}
+
+	m = s.Settings.Metadata().Get(to)
+	if nil == m || !helpers.SliceContainsString(m.PreviousIDs, from) {
+		return false, &ErrUnknownDevice{ID: from}
+	}
+
+	return true, nil
+}
+
+// Migrates device history to a new ID.
+func (s *GoHomeServer) renameHistory(from string, to string) error {
+	err := s.Settings.Storage().Rename(from, to)
+	if _, ok := err.(*storage.ErrRenameNotSupported); ok {
+		s.Logger.Warn("Device history is not migrated", common.LogSystemToken, logSystem,
+			common.LogIDToken, from, common.LogErrorToken, err.Error())
+		return nil
+	}
+
+	if err != nil {
+		s.Logger.Error("Failed to migrate device history, rename should be repeated", err,
+			common.LogSystemToken, logSystem, common.LogIDToken, to, "previous_id", from)
+	}
+
+	return err
+}
+
+// Checks device access, including rules written for previous device IDs.
+func (s *GoHomeServer) isAllowed(check func(string) bool, deviceID string) bool {
+	if check(deviceID) {
+		return true
+	}
+
+	m := s.Settings.Metadata().Get(deviceID)
+	if nil == m {
+		return false
+	}
+
+	for _, v := range m.PreviousIDs {
+		// Previous ID might be registered again by another device.
+		if nil != s.Settings.Metadata().Get(v) || nil != s.state.GetDevice(v) {
+			continue
+		}
+
+		if check(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Warns about known devices, which are not reported anymore and are similar to a new device.
+// Usually it means that config entry or plugin device name was changed.
+func (s *serverState) checkReplacedDevices(deviceID string) {
+	for k, v := range s.Settings.Metadata().All() {
+		if k == deviceID || 0 == v.FirstSeen {
+			continue
+		}
+
+		if _, ok := s.KnownDevices[k]; ok {
+			continue
+		}
+
+		if utils.StringSimilarity(k, deviceID) < similarDeviceThreshold {
+			continue
+		}
+
+		s.Logger.Warn("Device ID disappeared and a similar one appeared, consider renaming the device",
+			common.LogSystemToken, logSystem, common.LogIDToken, deviceID, "previous_id", k)
+	}
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/gobwas/glob"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems/bus"
+	"go-home.io/x/server/systems/fanout"
+	"go-home.io/x/server/systems/group"
+	"go-home.io/x/server/systems/metadata"
+	"go-home.io/x/server/systems/security"
+)
+
+// Tests device rename.
+func TestRenameDevice(t *testing.T) {
+	monkey.Patch(getContextUser, getFakeRootUser)
+	defer monkey.UnpatchAll()
+
+	srv := getServer()
+	srv.Settings.Metadata().Register("old")
+	require.NoError(t, srv.Settings.Metadata().Set("old", &providers.DeviceMetadata{Name: "Lamp"}), "set")
+
+	input := []struct {
+		from string
+		body string
+		code int
+	}{
+		{from: "old", body: `{"id": "old"}`, code: http.StatusInternalServerError},
+		{from: "old", body: `{"id": "dev2"}`, code: http.StatusInternalServerError},
+		{from: "old", body: `{"id": ""}`, code: http.StatusInternalServerError},
+		{from: "dev1", body: `{"id": "device"}`, code: http.StatusInternalServerError},
+		{from: "unknown", body: `{"id": "dev1"}`, code: http.StatusInternalServerError},
+		{from: "old", body: `{"id": "dev1"}`, code: http.StatusOK},
+	}
+
+	for _, v := range input {
+		req, err := http.NewRequest("POST", "/test", strings.NewReader(v.body))
+		require.NoError(t, err, "setup failed %s", v.body)
+		req = mux.SetURLVars(req, map[string]string{string(urlDeviceID): v.from})
+
+		r := httptest.NewRecorder()
+		http.HandlerFunc(srv.renameDevice).ServeHTTP(r, req)
+		assert.Equal(t, v.code, r.Code, "response code %s -> %s", v.from, v.body)
+	}
+
+	assert.Nil(t, srv.Settings.Metadata().Get("unknown"), "unknown device")
+
+	m := srv.Settings.Metadata().Get("dev1")
+	require.NotNil(t, m, "metadata")
+	assert.Equal(t, "Lamp", m.Name, "name")
+	assert.Equal(t, []string{"old"}, m.PreviousIDs, "previous")
+}
+
+// Tests that rules written for previous IDs are applied to renamed device.
+func TestRenamedDeviceAccess(t *testing.T) {
+	numCalled := 0
+	srv := getServer()
+	srv.Settings = getFakeSettings(func(_ string, _ ...interface{}) {
+		numCalled++
+	}, nil, nil)
+	srv.state.(*serverState).Settings = srv.Settings
+
+	user := &security.AuthenticatedUser{
+		Username: "usr1",
+		Rules: map[providers.SecSystem][]*providers.BakedRule{
+			providers.SecSystemDevice: {
+				{
+					Get:     true,
+					Command: true,
+					Resources: []glob.Glob{
+						compileRegexp("old"),
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, 0, len(srv.commandGetAllDevices(user)), "before rename")
+	srv.Settings.Metadata().Register("old")
+	assert.IsType(t, &ErrUnknownDevice{}, srv.commandRenameDevice(user, "old", []byte(`{"id": "dev1"}`)),
+		"rename without access")
+	require.NoError(t, srv.commandRenameDevice(getFakeRootUser(nil), "old", []byte(`{"id": "dev1"}`)))
+
+	devices := srv.commandGetAllDevices(user)
+	require.Equal(t, 1, len(devices), "after rename")
+	assert.Equal(t, "dev1", devices[0].ID, "device")
+	assert.False(t, devices[0].IsReadOnly, "read only")
+
+	require.NoError(t, srv.commandInvokeDeviceCommand(user, "dev1", enums.CmdOn.String(), nil), "command")
+	assert.Equal(t, 1, numCalled, "command sent")
+
+	srv.InternalCommandInvokeDeviceCommand(glob.MustCompile("old"), enums.CmdOn, nil)
+	assert.Equal(t, 2, numCalled, "internal command sent")
+
+	srv.Settings.Metadata().Register("old")
+	assert.Equal(t, 0, len(srv.commandGetAllDevices(user)), "previous ID registered again")
+}
+
+// Tests that triggers written for previous IDs are applied to renamed device.
+func TestRenamedDeviceTrigger(t *testing.T) {
+	commands := make(chan *bus.DeviceCommandMessage, 10)
+	srv := getServer()
+	srv.Settings = getFakeSettings(func(_ string, msg ...interface{}) {
+		commands <- msg[0].(*bus.DeviceCommandMessage)
+	}, nil, nil)
+	srv.state.(*serverState).Settings = srv.Settings
+
+	tr := &fakeTrigger{}
+	srv.Settings.(mocks.IFakeSettings).AddLoader(tr)
+	srv.Settings.(mocks.IFakeSettings).AddMasterComponents(nil, nil,
+		[]*providers.RawMasterComponent{{Name: "1", RawConfig: []byte(`
+name: 1
+actions:
+  - system: device
+    entity: old
+    command: "on"
+`)}})
+	srv.startTriggers()
+	require.NotNil(t, tr.triggered, "trigger")
+
+	srv.Settings.Metadata().Register("old")
+	require.NoError(t, srv.commandRenameDevice(getFakeRootUser(nil), "old", []byte(`{"id": "dev1"}`)))
+
+	tr.triggered <- true
+	select {
+	case msg := <-commands:
+		assert.Equal(t, "dev1", msg.DeviceID, "device")
+	case <-time.After(1 * time.Second):
+		assert.Fail(t, "trigger action was not invoked")
+	}
+}
+
+// Storage which fails history migration until allowed.
+type renameStorage struct {
+	providers.IStorageProvider
+	fail    bool
+	renamed []string
+}
+
+func (s *renameStorage) Rename(from string, to string) error {
+	if s.fail {
+		return errors.New("storage is not available")
+	}
+
+	s.renamed = append(s.renamed, from+":"+to)
+	return nil
+}
+
+// Settings with real fan-out and metadata providers.
+type renameSettings struct {
+	providers.ISettingsProvider
+	fanOut   providers.IInternalFanOutProvider
+	metadata providers.IMetadataProvider
+	storage  providers.IStorageProvider
+}
+
+func (s *renameSettings) FanOut() providers.IInternalFanOutProvider {
+	return s.fanOut
+}
+
+func (s *renameSettings) Metadata() providers.IMetadataProvider {
+	return s.metadata
+}
+
+func (s *renameSettings) Storage() providers.IStorageProvider {
+	return s.storage
+}
+
+// Tests that groups written for previous IDs pick up renamed device
+// and that failed history migration can be repeated.
+func TestRenamedDeviceGroup(t *testing.T) {
+	srv := getServer()
+	fanOut := fanout.NewFanOut()
+	st := &renameStorage{IStorageProvider: mocks.FakeNewStorage(), fail: true}
+	s := &renameSettings{
+		ISettingsProvider: srv.Settings,
+		fanOut:            fanOut,
+		metadata:          metadata.NewMetadataProvider(&metadata.ConstructMetadata{FanOut: fanOut}),
+		storage:           st,
+	}
+	srv.Settings = s
+	srv.state.(*serverState).Settings = s
+
+	g, err := group.NewGroupProvider(&group.ConstructGroup{
+		RawConfig: []byte("name: lamps\ndevices:\n  - old\n"),
+		Settings:  s,
+		Server:    srv,
+	})
+	require.NoError(t, err, "group")
+
+	fanOut.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{ID: "dev1"}
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 0, len(g.Devices()), "unmatched device was added")
+
+	s.metadata.Register("old")
+	err = srv.commandRenameDevice(getFakeRootUser(nil), "old", []byte(`{"id": "dev1"}`))
+	assert.Error(t, err, "storage failure")
+	assert.Equal(t, []string{"old"}, s.metadata.Get("dev1").PreviousIDs, "metadata")
+
+	st.fail = false
+	require.NoError(t, srv.commandRenameDevice(getFakeRootUser(nil), "old", []byte(`{"id": "dev1"}`)), "retry")
+	assert.Equal(t, []string{"old:dev1"}, st.renamed, "history")
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{"dev1"}, g.Devices(), "group")
+}
+
+// Tests warning about replaced devices.
+func TestReplacedDeviceWarning(t *testing.T) {
+	warnings := 0
+	s := mocks.FakeNewSettings(nil, false, nil, func(msg string) {
+		if strings.HasPrefix(msg, "Device ID disappeared") {
+			warnings++
+		}
+	})
+	state := newServerState(s)
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "hue.light.kitchen", DeviceType: enums.DevLight})
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "hue.light.kitchen_1", DeviceType: enums.DevLight})
+	assert.Equal(t, 0, warnings, "both devices are known")
+
+	delete(state.KnownDevices, "hue.light.kitchen")
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "hue.light.kitchen_2", DeviceType: enums.DevLight})
+	assert.Equal(t, 1, warnings, "old device disappeared")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "weather.station", DeviceType: enums.DevWeather})
+	assert.Equal(t, 1, warnings, "different device")
+}
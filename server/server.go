@@ -17,11 +17,13 @@ import (
 	"github.com/rakyll/statik/fs"
 	busPlugin "go-home.io/x/server/plugins/bus"
 	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/providers"
 	_ "go-home.io/x/server/server/statik" // Importing statik auto-generated files.
 	"go-home.io/x/server/systems/api"
 	"go-home.io/x/server/systems/bus"
 	"go-home.io/x/server/systems/group"
+	"go-home.io/x/server/systems/template"
 	"go-home.io/x/server/systems/trigger"
 	"go-home.io/x/server/systems/ui"
 )
@@ -43,6 +45,7 @@ type GoHomeServer struct {
 	Logger        common.ILoggerProvider
 	MessageParser bus.IMasterMessageParserProvider
 	incomingChan  chan busPlugin.RawMessage
+	busPool       bus.IProcessingPoolProvider
 
 	state IServerStateProvider
 
@@ -50,6 +53,7 @@ type GoHomeServer struct {
 	extendedAPIs []*knownMasterComponent
 	groups       map[string]providers.IGroupProvider
 	locations    []providers.ILocationProvider
+	locks        *locksSecurity
 
 	wsSettings websocket.Upgrader
 }
@@ -66,6 +70,12 @@ func NewServer(settings providers.ISettingsProvider) (providers.IServerProvider,
 	}
 
 	server.state = newServerState(settings)
+	server.locks = newLocksSecurity(settings.SystemLogger(), settings.DevicesConfig())
+	server.busPool = bus.NewProcessingPool(&bus.ConstructProcessingPool{
+		Logger:    settings.SystemLogger(),
+		Settings:  &settings.MasterSettings().BusQueue,
+		Processor: server.MessageParser.ProcessIncomingMessage,
+	})
 
 	return &server, nil
 }
@@ -76,6 +86,7 @@ func (s *GoHomeServer) Start() {
 
 	s.startTriggers()
 	s.startGroups()
+	s.startTemplates()
 	s.startLocations()
 
 	s.wsSettings = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool {
@@ -87,7 +98,7 @@ func (s *GoHomeServer) Start() {
 		err := http.ListenAndServe(fmt.Sprintf(":%d", s.Settings.MasterSettings().Port),
 			handlers.CORS(
 				handlers.AllowedOrigins([]string{"*"}),
-				handlers.AllowedMethods([]string{http.MethodGet, http.MethodPost}),
+				handlers.AllowedMethods([]string{http.MethodGet, http.MethodPost, http.MethodDelete}),
 				handlers.AllowedHeaders([]string{"Accept-Encoding", "Content-Type", "Connection",
 					"Host", "Origin", "User-Agent", "Referer", "Authorization"}),
 				handlers.AllowCredentials(),
@@ -130,6 +141,11 @@ func (s *GoHomeServer) GetDevice(ID string) *providers.KnownDevice {
 	}
 }
 
+// GetDeviceState returns known device state.
+func (s *GoHomeServer) GetDeviceState(ID string) map[enums.Property]interface{} {
+	return s.state.GetDeviceState(ID)
+}
+
 // PushMasterDeviceUpdate pushed device to known devices state
 func (s *GoHomeServer) PushMasterDeviceUpdate(update *providers.MasterDeviceUpdate) {
 	msg := &bus.DeviceUpdateMessage{
@@ -165,6 +181,21 @@ func (s *GoHomeServer) registerAPI(router *mux.Router) {
 	apiRouter.HandleFunc("/state", s.getCurrentState).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/worker", s.getWorkers).Methods(http.MethodGet)
 	apiRouter.HandleFunc("/status", s.getStatus).Methods(http.MethodGet)
+	apiRouter.HandleFunc("/metadata", s.getMetadata).Methods(http.MethodGet)
+	apiRouter.HandleFunc(fmt.Sprintf("/metadata/{%s}", urlDeviceID),
+		s.setMetadata).Methods(http.MethodPost)
+	apiRouter.HandleFunc(fmt.Sprintf("/metadata/{%s}", urlDeviceID),
+		s.deleteMetadata).Methods(http.MethodDelete)
+	apiRouter.HandleFunc(fmt.Sprintf("/rename/{%s}", urlDeviceID),
+		s.renameDevice).Methods(http.MethodPost)
+	apiRouter.HandleFunc(fmt.Sprintf("/camera/{%s}/snapshot", urlDeviceID),
+		s.getCameraSnapshot).Methods(http.MethodGet)
+	apiRouter.HandleFunc(fmt.Sprintf("/camera/{%s}/stream", urlDeviceID),
+		s.getCameraStream).Methods(http.MethodGet)
+	apiRouter.HandleFunc(fmt.Sprintf("/snapshot/{%s}", urlDeviceID),
+		s.getSnapshots).Methods(http.MethodGet)
+	apiRouter.HandleFunc(fmt.Sprintf("/snapshot/{%s}/{%s}", urlDeviceID, urlSnapshotID),
+		s.getSnapshot).Methods(http.MethodGet)
 
 	apiRouter.Use(s.logMiddleware)
 	router.Use(s.authMiddleware)
@@ -188,6 +219,7 @@ func (s *GoHomeServer) busStart() {
 	}
 
 	s.Logger.Debug("Successfully subscribed to bus channels", common.LogSystemToken, logSystem)
+	go s.busPool.Listen(s.incomingChan)
 	s.busCycle()
 }
 
@@ -195,14 +227,16 @@ func (s *GoHomeServer) busStart() {
 func (s *GoHomeServer) busCycle() {
 	for {
 		select {
-		case msg := <-s.incomingChan:
-			go s.MessageParser.ProcessIncomingMessage(&msg)
 		case dis := <-s.MessageParser.GetDiscoveryMessageChan():
 			s.state.Discovery(dis)
 		case dup := <-s.MessageParser.GetDeviceUpdateMessageChan():
 			s.state.Update(dup)
 		case load := <-s.MessageParser.GetEntityLoadStatueMessageChan():
 			s.state.EntityLoad(load)
+		case rm := <-s.MessageParser.GetDeviceRemovedMessageChan():
+			s.state.Remove(rm)
+		case evt := <-s.MessageParser.GetDeviceEventMessageChan():
+			s.state.Event(evt)
 		}
 	}
 }
@@ -292,6 +326,19 @@ func (s *GoHomeServer) startGroups() {
 	}
 }
 
+// Starts template devices.
+func (s *GoHomeServer) startTemplates() {
+	for _, v := range s.Settings.Templates() {
+		ctor := &template.ConstructTemplate{
+			RawConfig: v.RawConfig,
+			Settings:  s.Settings,
+			Server:    s,
+		}
+
+		template.NewTemplateProvider(ctor) // nolint: gosec
+	}
+}
+
 // Starts locations.
 func (s *GoHomeServer) startLocations() {
 	s.locations = make([]providers.ILocationProvider, 0)
@@ -301,6 +348,7 @@ func (s *GoHomeServer) startLocations() {
 			RawConfig: v.RawConfig,
 			Logger:    s.Settings.SystemLogger(),
 			FanOut:    s.Settings.FanOut(),
+			Metadata:  s.Settings.Metadata(),
 		}
 
 		l, err := ui.NewLocationProvider(ctor)
@@ -30,12 +30,17 @@ func (*fakeAPI) Unload() {
 }
 
 type fakeTrigger struct {
+	triggered chan interface{}
 }
 
 func (*fakeTrigger) Init(*trigger.InitDataTrigger) error {
 	return nil
 }
 
+func (f *fakeTrigger) FakeInit(data interface{}) {
+	f.triggered = data.(*trigger.InitDataTrigger).Triggered
+}
+
 // Tests success components loading.
 func TestSuccessGroupsAPILoad(t *testing.T) {
 	s := getFakeSettings(func(_ string, _ ...interface{}) {}, nil, nil)
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"encoding/base64"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -22,8 +24,11 @@ type IServerStateProvider interface {
 	Discovery(msg *bus.DiscoveryMessage)
 	Update(msg *bus.DeviceUpdateMessage)
 	EntityLoad(msg *bus.EntityLoadStatusMessage)
+	Remove(msg *bus.DeviceRemovedMessage)
+	Event(msg *bus.DeviceEventMessage)
 	GetAllDevices() []*knownDevice
 	GetDevice(string) *knownDevice
+	GetDeviceState(string) map[enums.Property]interface{}
 	GetWorkers() []*knownWorker
 	GetEntities() []*knownEntity
 }
@@ -35,6 +40,9 @@ type knownWorker struct {
 	WorkerProperties map[string]string       `json:"worker_properties"`
 	Devices          []*bus.DeviceAssignment `json:"-"`
 	MaxDevices       int                     `json:"max_devices"`
+	ClockSkew        int64                   `json:"clock_skew"`
+	ClockSkewWarning bool                    `json:"clock_skew_warning"`
+	BusQueue         *bus.QueueStats         `json:"bus_queue,omitempty"`
 }
 
 // Config entities.
@@ -57,7 +65,8 @@ type serverState struct {
 	workerMutex *sync.Mutex
 	deviceMutex *sync.Mutex
 
-	fanOut providers.IInternalFanOutProvider
+	fanOut         providers.IInternalFanOutProvider
+	resyncRequests map[string]int64
 }
 
 // Constructs a new server state.
@@ -72,7 +81,8 @@ func newServerState(settings providers.ISettingsProvider) *serverState {
 		workerMutex: &sync.Mutex{},
 		deviceMutex: &sync.Mutex{},
 
-		fanOut: settings.FanOut(),
+		fanOut:         settings.FanOut(),
+		resyncRequests: make(map[string]int64),
 	}
 
 	for _, v := range s.Settings.DevicesConfig() {
@@ -113,8 +123,9 @@ func (s *serverState) Discovery(msg *bus.DiscoveryMessage) {
 					common.LogWorkerToken, msg.NodeID, common.LogSystemToken, logSystem)
 			}
 
-			s.Settings.ServiceBus().PublishToWorker(msg.NodeID, bus.NewDeviceAssignmentMessage(wk.Devices,
-				s.Settings.MasterSettings().UOM))
+			reply := bus.NewDeviceAssignmentMessage(wk.Devices, s.Settings.MasterSettings().UOM)
+			reply.PingTime = msg.PingTime
+			s.Settings.ServiceBus().PublishToWorker(msg.NodeID, reply)
 			syncProperties = false
 			reBalanceNeeded = false
 
@@ -138,6 +149,8 @@ func (s *serverState) Discovery(msg *bus.DiscoveryMessage) {
 	}
 	wk.LastSeen = utils.TimeNow()
 	wk.MaxDevices = msg.MaxDevices
+	wk.BusQueue = msg.BusQueue
+	s.updateClockSkew(wk, msg)
 
 	if syncProperties {
 		wk.WorkerProperties = make(map[string]string, len(msg.Properties)+1)
@@ -152,6 +165,24 @@ func (s *serverState) Discovery(msg *bus.DiscoveryMessage) {
 	}
 }
 
+// Updates worker's clock skew in milliseconds.
+// Discovery messages are sent with worker's local time, so skew includes
+// delivery latency, which is negligible comparing to the warning threshold.
+func (s *serverState) updateClockSkew(wk *knownWorker, msg *bus.DiscoveryMessage) {
+	if 0 == msg.PingTime {
+		return
+	}
+
+	wk.ClockSkew = utils.TimeNowMs() - msg.PingTime
+	isLarge := bus.IsLargeClockSkew(wk.ClockSkew)
+	if isLarge && !wk.ClockSkewWarning {
+		s.Logger.Warn("Worker's clock is out of sync with master", common.LogWorkerToken, msg.NodeID,
+			common.LogSystemToken, logSystem, "skew_ms", strconv.FormatInt(wk.ClockSkew, 10))
+	}
+
+	wk.ClockSkewWarning = isLarge
+}
+
 // Update processes incoming device update message.
 func (s *serverState) Update(msg *bus.DeviceUpdateMessage) {
 	s.Logger.Debug("Received update for the device", common.LogDeviceTypeToken, msg.DeviceType.String(),
@@ -164,6 +195,9 @@ func (s *serverState) Update(msg *bus.DeviceUpdateMessage) {
 	var dv *knownDevice
 	if d, ok := s.KnownDevices[msg.DeviceID]; ok {
 		dv = d
+	} else if msg.IsDelta {
+		s.requestResync(msg)
+		return
 	} else {
 		firstOccurrence = true
 		dv = &knownDevice{
@@ -176,13 +210,125 @@ func (s *serverState) Update(msg *bus.DeviceUpdateMessage) {
 
 		copy(dv.Commands, msg.Commands)
 		s.KnownDevices[msg.DeviceID] = dv
+
+		if s.Settings.Metadata().Register(msg.DeviceID) {
+			s.checkReplacedDevices(msg.DeviceID)
+		}
 	}
 
+	if msg.IsDelta {
+		if msg.Version <= dv.Version {
+			s.requestResync(msg)
+			return
+		}
+
+		if msg.Version != dv.Version+1 {
+			s.requestResync(msg)
+		}
+	} else {
+		dv.Commands = msg.Commands
+		dv.Capabilities = msg.Capabilities
+	}
+
+	dv.Version = msg.Version
 	dv.LastSeen = utils.TimeNow()
 	dv.Worker = msg.WorkerID
-	dv.Commands = msg.Commands
 
-	s.processDeviceStateUpdate(dv, msg.State, firstOccurrence)
+	availabilityChanged := !firstOccurrence && dv.Available == msg.Unavailable
+	dv.Available = !msg.Unavailable
+
+	s.processDeviceStateUpdate(dv, msg.State, firstOccurrence, availabilityChanged)
+}
+
+// Remove processes device removal, reported by the worker.
+// User-defined metadata is kept, so device gets it back if it re-appears.
+func (s *serverState) Remove(msg *bus.DeviceRemovedMessage) {
+	s.deviceMutex.Lock()
+	defer s.deviceMutex.Unlock()
+
+	dv, ok := s.KnownDevices[msg.DeviceID]
+	if !ok {
+		return
+	}
+
+	if dv.Worker != msg.WorkerID {
+		s.Logger.Warn("Received device removal from a wrong worker", common.LogSystemToken, logSystem,
+			common.LogIDToken, msg.DeviceID, "expected", dv.Worker, "actual", msg.WorkerID)
+		return
+	}
+
+	s.Logger.Info("Removing device", common.LogSystemToken, logSystem,
+		common.LogIDToken, msg.DeviceID, common.LogWorkerToken, msg.WorkerID)
+	delete(s.KnownDevices, msg.DeviceID)
+	delete(s.resyncRequests, msg.DeviceID)
+
+	s.fanOut.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:      dv.ID,
+		Name:    dv.Name,
+		Type:    dv.Type,
+		State:   make(map[enums.Property]interface{}),
+		Removed: true,
+	}
+}
+
+// Event processes momentary device event, reported by the worker.
+// Events are not stored and only passed to subscribers.
+func (s *serverState) Event(msg *bus.DeviceEventMessage) {
+	s.deviceMutex.Lock()
+	dv, ok := s.KnownDevices[msg.DeviceID]
+	if !ok {
+		s.deviceMutex.Unlock()
+		s.Logger.Debug("Received event for unknown device", common.LogSystemToken, logSystem,
+			common.LogIDToken, msg.DeviceID, common.LogWorkerToken, msg.WorkerID)
+		return
+	}
+
+	if dv.Worker != msg.WorkerID {
+		s.deviceMutex.Unlock()
+		s.Logger.Warn("Received device event from a wrong worker", common.LogSystemToken, logSystem,
+			common.LogIDToken, msg.DeviceID, "expected", dv.Worker, "actual", msg.WorkerID)
+		return
+	}
+
+	evt := &common.MsgDeviceEvent{
+		ID:    dv.ID,
+		Name:  dv.Name,
+		Type:  dv.Type,
+		Event: msg.Event,
+		Data:  msg.Data,
+	}
+	s.deviceMutex.Unlock()
+
+	s.fanOut.ChannelInDeviceEvents() <- evt
+}
+
+// Stores camera picture in the snapshots archive.
+func (s *serverState) archiveSnapshot(deviceID string, picture string) {
+	data, err := base64.StdEncoding.DecodeString(picture)
+	if err != nil {
+		s.Logger.Error("Failed to decode camera picture", err, common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID)
+		return
+	}
+
+	err = s.Settings.Snapshots().Add(deviceID, data)
+	if err != nil {
+		s.Logger.Error("Failed to archive camera picture", err, common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID)
+	}
+}
+
+// Requests device's full state from the worker.
+// Requests are throttled, since a single gap usually produces several out of order updates.
+func (s *serverState) requestResync(msg *bus.DeviceUpdateMessage) {
+	if utils.TimeNow()-s.resyncRequests[msg.DeviceID] < deviceResyncInterval {
+		return
+	}
+
+	s.Logger.Debug("Detected a gap in device updates, requesting resync", common.LogSystemToken, logSystem,
+		common.LogIDToken, msg.DeviceID, common.LogWorkerToken, msg.WorkerID)
+	s.resyncRequests[msg.DeviceID] = utils.TimeNow()
+	s.Settings.ServiceBus().PublishToWorker(msg.WorkerID, bus.NewDeviceResyncMessage(msg.DeviceID))
 }
 
 // EntityLoad processes entity load status.
@@ -233,6 +379,37 @@ func (s *serverState) GetDevice(deviceID string) *knownDevice {
 	return s.KnownDevices[deviceID]
 }
 
+// GetDeviceState returns a copy of known device state with typed properties.
+// Returns nil for unknown devices.
+func (s *serverState) GetDeviceState(deviceID string) map[enums.Property]interface{} {
+	s.deviceMutex.Lock()
+	defer s.deviceMutex.Unlock()
+
+	dv, ok := s.KnownDevices[deviceID]
+	if !ok {
+		return nil
+	}
+
+	state := make(map[enums.Property]interface{}, len(dv.State))
+	for k, v := range dv.State {
+		prop, err := enums.PropertyString(k)
+		if err != nil {
+			continue
+		}
+
+		if dv.Type != enums.DevGroup {
+			v, err = helpers.PropertyFixYaml(v, prop)
+			if err != nil {
+				continue
+			}
+		}
+
+		state[prop] = v
+	}
+
+	return state
+}
+
 // GetWorkers returns known workers.
 // nolint: dupl
 func (s *serverState) GetWorkers() []*knownWorker {
@@ -262,7 +439,9 @@ func (s *serverState) GetEntities() []*knownEntity {
 }
 
 // Processes device state updates.
-func (s *serverState) processDeviceStateUpdate(dv *knownDevice, newState map[string]interface{}, firstOccurrence bool) {
+// Availability is sent through the fan-out as a regular property, so triggers can react on it.
+func (s *serverState) processDeviceStateUpdate(dv *knownDevice, newState map[string]interface{},
+	firstOccurrence bool, availabilityChanged bool) {
 	msg := &common.MsgDeviceUpdate{
 		ID:        dv.ID,
 		State:     make(map[enums.Property]interface{}),
@@ -270,6 +449,7 @@ func (s *serverState) processDeviceStateUpdate(dv *knownDevice, newState map[str
 		Name:      dv.Name,
 		Type:      dv.Type,
 	}
+	prevPicture, _ := dv.State[enums.PropPicture.String()].(string)
 	for k, v := range newState {
 		prop, err := enums.PropertyString(k)
 		if err != nil {
@@ -310,11 +490,23 @@ func (s *serverState) processDeviceStateUpdate(dv *knownDevice, newState map[str
 		}
 	}
 
+	if availabilityChanged {
+		s.Logger.Info("Device availability changed", common.LogSystemToken, logSystem,
+			common.LogIDToken, dv.ID, enums.PropAvailable.String(), strconv.FormatBool(dv.Available))
+		msg.State[enums.PropAvailable] = dv.Available
+	}
+
 	if 0 != len(msg.State) {
 		s.fanOut.ChannelInDeviceUpdates() <- msg
 		go s.Settings.Storage().State(msg)
 	}
 
+	// Keyframes repeat the last picture, so only new ones are archived.
+	picture, ok := msg.State[enums.PropPicture].(string)
+	if ok && "" != picture && picture != prevPicture && enums.DevCamera == dv.Type {
+		go s.archiveSnapshot(dv.ID, picture)
+	}
+
 	go s.Settings.Storage().Heartbeat(dv.ID)
 }
 
@@ -522,6 +714,23 @@ func (s *serverState) checkStaleWorkers() {
 
 	s.workerMutex.Unlock()
 	if len(toDelete) > 0 {
+		s.markWorkerDevicesUnavailable(toDelete)
 		s.reBalance("")
 	}
 }
+
+// Marks devices hosted by stale workers as unavailable.
+// Devices become available again with the first update from a new worker.
+func (s *serverState) markWorkerDevicesUnavailable(workers []string) {
+	s.deviceMutex.Lock()
+	defer s.deviceMutex.Unlock()
+
+	for _, v := range s.KnownDevices {
+		if !v.Available || !helpers.SliceContainsString(workers, v.Worker) {
+			continue
+		}
+
+		v.Available = false
+		s.processDeviceStateUpdate(v, nil, false, true)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go-home.io/x/server/mocks"
 	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/plugins/helpers"
 	"go-home.io/x/server/providers"
 	"go-home.io/x/server/systems/bus"
@@ -413,6 +414,40 @@ func TestPingMessageNoReBalance(t *testing.T) {
 	assert.Equal(t, 1, len(published), "calls")
 }
 
+// Tests whether discovery reply is used for clock sync and large skew is reported.
+func TestPingMessageClockSkew(t *testing.T) {
+	var reply *bus.DeviceAssignmentMessage
+	logInvoked := false
+	s := getFakeSettings(func(name string, msg ...interface{}) {
+		reply = msg[0].(*bus.DeviceAssignmentMessage)
+	}, nil, func(s string) {
+		if s == "Worker's clock is out of sync with master" {
+			logInvoked = true
+		}
+	})
+	state := newServerState(s)
+
+	state.KnownWorkers["1"] = &knownWorker{
+		ID:               "1",
+		WorkerProperties: map[string]string{"name": "1"},
+		MaxDevices:       999,
+	}
+
+	pingTime := utils.TimeNowMs() - 60*1000
+	discovery := &bus.DiscoveryMessage{
+		MaxDevices: 999,
+		NodeID:     "1",
+		PingTime:   pingTime,
+	}
+	state.Discovery(discovery)
+
+	require.NotNil(t, reply, "reply")
+	assert.Equal(t, pingTime, reply.PingTime, "ping time")
+	assert.True(t, logInvoked, "log")
+	assert.True(t, state.KnownWorkers["1"].ClockSkewWarning, "warning")
+	assert.InDelta(t, 60*1000, state.KnownWorkers["1"].ClockSkew, 1000, "skew")
+}
+
 // Tests whether after reboot worker will receive it's device
 // assignment back.
 func TestWorkerRestartNoReBalance(t *testing.T) {
@@ -967,3 +1002,138 @@ func TestEntityLoad(t *testing.T) {
 	require.Equal(t, 1, len(state.KnownEntities), "didn't receive entity third time")
 	assert.Equal(t, entityLoadFailed, state.KnownEntities["test"].Status, "wrong status third time")
 }
+
+// Tests that gaps in delta updates trigger device resync.
+func TestDeltaUpdatesResync(t *testing.T) {
+	resyncs := make([]string, 0)
+	s := getFakeSettings(func(name string, msg ...interface{}) {
+		resyncs = append(resyncs, msg[0].(*bus.DeviceResyncMessage).DeviceID)
+	}, nil, nil)
+	state := newServerState(s)
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 1, IsDelta: true})
+	assert.Equal(t, []string{"test"}, resyncs, "unknown device")
+	assert.Equal(t, 0, len(state.KnownDevices), "unknown device added")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 2,
+		State: map[string]interface{}{"brightness": 50}})
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 3, IsDelta: true,
+		State: map[string]interface{}{"brightness": 60}})
+	assert.Equal(t, 1, len(resyncs), "sequential delta")
+	assert.Equal(t, uint64(3), state.KnownDevices["test"].Version, "sequential version")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 5, IsDelta: true,
+		State: map[string]interface{}{"brightness": 70}})
+	assert.Equal(t, 1, len(resyncs), "throttled resync")
+	assert.Equal(t, uint64(5), state.KnownDevices["test"].Version, "gap version")
+
+	state.resyncRequests["test"] = 0
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 4, IsDelta: true,
+		State: map[string]interface{}{"brightness": 10}})
+	assert.Equal(t, 2, len(resyncs), "outdated delta")
+	assert.Equal(t, uint64(5), state.KnownDevices["test"].Version, "outdated version")
+}
+
+// Tests that availability changes are sent through the fan-out.
+func TestDeviceAvailability(t *testing.T) {
+	s := getFakeSettings(nil, nil, nil)
+	state := newServerState(s)
+	updates := s.FanOut().ChannelInDeviceUpdates()
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 1,
+		State: map[string]interface{}{"on": true}})
+	assert.True(t, state.KnownDevices["test"].Available, "first update")
+	msg := <-updates
+	_, ok := msg.State[enums.PropAvailable]
+	assert.False(t, ok, "first update fan-out")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 2, IsDelta: true,
+		Unavailable: true, State: map[string]interface{}{}})
+	assert.False(t, state.KnownDevices["test"].Available, "unavailable")
+	msg = <-updates
+	assert.Equal(t, false, msg.State[enums.PropAvailable], "unavailable fan-out")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", Version: 3, IsDelta: true,
+		State: map[string]interface{}{}})
+	msg = <-updates
+	assert.Equal(t, true, msg.State[enums.PropAvailable], "available fan-out")
+	assert.Equal(t, 0, len(updates), "extra fan-out")
+
+	state.KnownWorkers["w"] = &knownWorker{ID: "w", LastSeen: 0}
+	state.checkStaleWorkers()
+	assert.False(t, state.KnownDevices["test"].Available, "stale worker")
+	msg = <-updates
+	assert.Equal(t, map[enums.Property]interface{}{enums.PropAvailable: false}, msg.State, "stale worker fan-out")
+}
+
+// Tests device removal.
+func TestDeviceRemoval(t *testing.T) {
+	s := getFakeSettings(nil, nil, nil)
+	state := newServerState(s)
+	updates := s.FanOut().ChannelInDeviceUpdates()
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", DeviceName: "test device", WorkerID: "w",
+		DeviceType: enums.DevSwitch, Version: 1, State: map[string]interface{}{"on": true}})
+	<-updates
+
+	state.Remove(bus.NewDeviceRemovedMessage("test", "w1"))
+	assert.NotNil(t, state.GetDevice("test"), "wrong worker")
+	assert.Equal(t, 0, len(updates), "wrong worker fan-out")
+
+	state.Remove(bus.NewDeviceRemovedMessage("test", "w"))
+	assert.Nil(t, state.GetDevice("test"), "device was not removed")
+	msg := <-updates
+	assert.True(t, msg.Removed, "removed fan-out")
+	assert.Equal(t, "test", msg.ID, "removed id")
+	assert.Equal(t, enums.DevSwitch, msg.Type, "removed type")
+
+	state.Remove(bus.NewDeviceRemovedMessage("test", "w"))
+	assert.Equal(t, 0, len(updates), "unknown device fan-out")
+}
+
+// Tests device events processing.
+func TestDeviceEvent(t *testing.T) {
+	s := getFakeSettings(nil, nil, nil)
+	state := newServerState(s)
+	updates := s.FanOut().ChannelInDeviceUpdates()
+	events := s.FanOut().ChannelInDeviceEvents()
+
+	state.Event(bus.NewDeviceEventMessage("test", "w", enums.EvtClick, nil))
+	assert.Equal(t, 0, len(events), "unknown device fan-out")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", DeviceName: "test device", WorkerID: "w",
+		DeviceType: enums.DevSwitch, Version: 1, State: map[string]interface{}{"on": true}})
+	<-updates
+
+	state.Event(bus.NewDeviceEventMessage("test", "w1", enums.EvtClick, nil))
+	assert.Equal(t, 0, len(events), "wrong worker fan-out")
+
+	state.Event(bus.NewDeviceEventMessage("test", "w", enums.EvtLongPress,
+		map[string]interface{}{"button": 2}))
+	msg := <-events
+	assert.Equal(t, "test", msg.ID, "event id")
+	assert.Equal(t, enums.DevSwitch, msg.Type, "event type")
+	assert.Equal(t, enums.EvtLongPress, msg.Event, "event")
+	assert.Equal(t, 2, msg.Data["button"], "event data")
+	assert.Equal(t, 0, len(updates), "state fan-out")
+	assert.Equal(t, map[string]interface{}{"on": true}, state.GetDevice("test").State, "state changed")
+}
+
+// Tests typed device state copy.
+func TestGetDeviceState(t *testing.T) {
+	s := getFakeSettings(nil, nil, nil)
+	state := newServerState(s)
+	assert.Nil(t, state.GetDeviceState("test"), "unknown device")
+
+	state.Update(&bus.DeviceUpdateMessage{DeviceID: "test", WorkerID: "w", DeviceType: enums.DevSensor,
+		Version: 1, State: map[string]interface{}{"on": true, "power": 10.5, "unknown": 1}})
+	<-s.FanOut().ChannelInDeviceUpdates()
+
+	typed := state.GetDeviceState("test")
+	assert.Equal(t, 2, len(typed), "properties")
+	assert.Equal(t, true, typed[enums.PropOn], "on")
+	assert.Equal(t, common.Float{Value: 10.5}, typed[enums.PropPower], "power")
+
+	typed[enums.PropOn] = false
+	assert.Equal(t, true, state.GetDevice("test").State["on"], "copy")
+}
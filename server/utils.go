@@ -33,9 +33,43 @@ func respond(writer http.ResponseWriter, data interface{}) {
 	writer.Write(d) // nolint: gosec
 }
 
+// Command confirmation API response.
+type confirmationResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+	Token  string `json:"token"`
+}
+
+// Constructs command confirmation response.
+func newConfirmationResponse(err *ErrConfirmationRequired) *confirmationResponse {
+	return &confirmationResponse{
+		Status: "CONFIRM",
+		ID:     err.ID,
+		Token:  err.Token,
+	}
+}
+
+// HTTP_202 API response with the confirmation token.
+//noinspection GoUnhandledErrorResult
+func respondConfirmation(writer http.ResponseWriter, err *ErrConfirmationRequired) {
+	d, e := json.Marshal(newConfirmationResponse(err))
+	if e != nil {
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusAccepted)
+	writer.Write(d) // nolint: gosec
+}
+
 // Validates whether error is not null and responds different status
 // depending on it.
 func respondOkError(writer http.ResponseWriter, err error) {
+	if c, ok := err.(*ErrConfirmationRequired); ok {
+		respondConfirmation(writer, c)
+		return
+	}
+
 	if err != nil {
 		respondError(writer, err.Error())
 	} else {
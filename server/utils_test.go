@@ -2,6 +2,8 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -132,3 +134,30 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Equal(t, v.nextExpected, nextCalled, "call %s", v.url)
 	}
 }
+
+// Tests responses depending on the command error.
+func TestRespondOkError(t *testing.T) {
+	in := []struct {
+		err    error
+		code   int
+		status string
+	}{
+		{err: nil, code: http.StatusOK, status: "OK"},
+		{err: errors.New("test"), code: http.StatusInternalServerError, status: "ERROR"},
+		{err: &ErrConfirmationRequired{ID: "dev1", Token: "token"}, code: http.StatusAccepted, status: "CONFIRM"},
+	}
+
+	for _, v := range in {
+		r := httptest.NewRecorder()
+		respondOkError(r, v.err)
+		assert.Equal(t, v.code, r.Code, "code %s", v.status)
+
+		resp := &confirmationResponse{}
+		assert.NoError(t, json.Unmarshal(r.Body.Bytes(), resp), "json %s", v.status)
+		assert.Equal(t, v.status, resp.Status, "status %s", v.status)
+		if http.StatusAccepted == v.code {
+			assert.Equal(t, "dev1", resp.ID, "id")
+			assert.Equal(t, "token", resp.Token, "token")
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/providers"
 )
 
@@ -15,6 +16,15 @@ type wsCmd struct {
 	Val interface{} `json:"value"`
 }
 
+// Momentary device event, sent to WS clients.
+type wsDeviceEvent struct {
+	ID    string                 `json:"id"`
+	Name  string                 `json:"name"`
+	Type  enums.DeviceType       `json:"type"`
+	Event enums.DeviceEvent      `json:"event"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
 // Handles WS upgrade request.
 func (s *GoHomeServer) handleWS(writer http.ResponseWriter, request *http.Request) {
 	usr := getContextUser(request)
@@ -31,9 +41,12 @@ func (s *GoHomeServer) handleWS(writer http.ResponseWriter, request *http.Reques
 //noinspection GoUnhandledErrorResult
 func (s *GoHomeServer) processWSConnection(conn *websocket.Conn, usr providers.IAuthenticatedUser) {
 	stop := make(chan bool, 1)
-	go s.processIncomingWSMessages(conn, stop, usr)
+	replies := make(chan interface{}, 10)
+	go s.processIncomingWSMessages(conn, stop, replies, usr)
 	subID, upd := s.Settings.FanOut().SubscribeDeviceUpdates()
 	defer s.Settings.FanOut().UnSubscribeDeviceUpdates(subID)
+	evtID, evt := s.Settings.FanOut().SubscribeDeviceEvents()
+	defer s.Settings.FanOut().UnSubscribeDeviceEvents(evtID)
 
 	for {
 		select {
@@ -41,24 +54,49 @@ func (s *GoHomeServer) processWSConnection(conn *websocket.Conn, usr providers.I
 			if msg {
 				return
 			}
+		case msg := <-replies:
+			conn.WriteJSON(msg) // nolint: gosec
 		case msg, ok := <-upd:
 			{
 				if !ok {
 					return
 				}
 
+				if msg.Removed {
+					if s.isAllowed(usr.DeviceGet, msg.ID) {
+						kd := &knownDevice{ID: msg.ID, Name: msg.Name, Type: msg.Type, Removed: true}
+						conn.WriteJSON(kd) // nolint: gosec
+					}
+					break
+				}
+
 				kd := s.state.GetDevice(msg.ID)
-				if usr.DeviceGet(kd.ID) {
+				if nil != kd && s.isAllowed(usr.DeviceGet, kd.ID) {
 					conn.WriteJSON(kd) // nolint: gosec
 				}
 			}
+		case msg, ok := <-evt:
+			if !ok {
+				return
+			}
+
+			if s.isAllowed(usr.DeviceGet, msg.ID) {
+				conn.WriteJSON(&wsDeviceEvent{ // nolint: gosec
+					ID:    msg.ID,
+					Name:  msg.Name,
+					Type:  msg.Type,
+					Event: msg.Event,
+					Data:  msg.Data,
+				})
+			}
 		}
 	}
 }
 
 // Processes incoming WS messages.
+// Command replies are written by the connection processing routine.
 //noinspection GoUnhandledErrorResult
-func (s *GoHomeServer) processIncomingWSMessages(conn *websocket.Conn, stop chan bool,
+func (s *GoHomeServer) processIncomingWSMessages(conn *websocket.Conn, stop chan bool, replies chan interface{},
 	usr providers.IAuthenticatedUser) {
 	defer conn.Close()
 	for {
@@ -88,6 +126,13 @@ func (s *GoHomeServer) processIncomingWSMessages(conn *websocket.Conn, stop chan
 			continue
 		}
 
-		s.commandInvokeDeviceCommand(usr, cmd.ID, cmd.Cmd, data) // nolint: gosec
+		err = s.commandInvokeDeviceCommand(usr, cmd.ID, cmd.Cmd, data)
+		if c, ok := err.(*ErrConfirmationRequired); ok {
+			select {
+			case replies <- newConfirmationResponse(c):
+			default:
+				s.Logger.Warn("Dropping WS confirmation reply", common.LogUserNameToken, usr.Name())
+			}
+		}
 	}
 }
@@ -51,6 +51,7 @@ func (w *wsSuite) SetupTest() {
 			Worker: "1", State: map[string]interface{}{"test": "test"}},
 		"device": {ID: "device", Commands: []string{enums.CmdOn.String()}, Worker: "2"},
 		"g1":     {ID: "g1", Type: enums.DevGroup, Commands: []string{enums.CmdOn.String()}, Worker: "1"},
+		"dev3":   {ID: "dev3", Type: enums.DevLock, Commands: []string{enums.CmdUnlock.String()}, Worker: "1"},
 	}
 
 	user := &security.AuthenticatedUser{
@@ -78,6 +79,9 @@ func (w *wsSuite) SetupTest() {
 				w.group = true
 			}),
 		},
+		locks: newLocksSecurity(mocks.FakeNewLogger(nil), []*providers.RawDevice{
+			{Name: "dev3", StrConfig: "lockConfirmation: true"},
+		}),
 	}
 
 	w.ts = httptest.NewServer(http.HandlerFunc(srv.handleWS))
@@ -183,6 +187,53 @@ func (w *wsSuite) TestUpdate() {
 	assert.Equal(w.T(), "test", d.State["test"].(string), "wrong state")
 }
 
+// Tests event callbacks.
+//noinspection GoUnhandledErrorResult
+func (w *wsSuite) TestEvent() {
+	w.s.FanOut().ChannelInDeviceEvents() <- &common.MsgDeviceEvent{
+		ID:    "device",
+		Event: enums.EvtClick,
+	}
+	w.s.FanOut().ChannelInDeviceEvents() <- &common.MsgDeviceEvent{
+		ID:    "dev1",
+		Event: enums.EvtDoubleClick,
+		Data:  map[string]interface{}{"button": "left"},
+	}
+
+	w.ws.SetReadDeadline(time.Now().Add(1 * time.Second))
+	wt, msg, err := w.ws.ReadMessage()
+	require.NoError(w.T(), err, "error")
+	require.Equal(w.T(), websocket.TextMessage, wt, "type")
+
+	e := &wsDeviceEvent{}
+	err = json.Unmarshal(msg, e)
+	require.NoError(w.T(), err, "json")
+	assert.Equal(w.T(), "dev1", e.ID, "wrong device")
+	assert.Equal(w.T(), enums.EvtDoubleClick, e.Event, "wrong event")
+	assert.Equal(w.T(), "left", e.Data["button"], "wrong data")
+}
+
+// Tests lock confirmation reply.
+//noinspection GoUnhandledErrorResult
+func (w *wsSuite) TestConfirmation() {
+	w.ws.WriteJSON(&wsCmd{
+		ID:  "dev3",
+		Cmd: "unlock",
+	})
+
+	w.ws.SetReadDeadline(time.Now().Add(1 * time.Second))
+	_, msg, err := w.ws.ReadMessage()
+	require.NoError(w.T(), err, "error")
+
+	c := &confirmationResponse{}
+	err = json.Unmarshal(msg, c)
+	require.NoError(w.T(), err, "json")
+	assert.Equal(w.T(), "CONFIRM", c.Status, "status")
+	assert.Equal(w.T(), "dev3", c.ID, "device")
+	assert.NotEmpty(w.T(), c.Token, "token")
+	assert.False(w.T(), w.worker1, "published")
+}
+
 // Tests WS connection.
 func TestWs(t *testing.T) {
 	suite.Run(t, new(wsSuite))
@@ -16,8 +16,10 @@ import (
 	"go-home.io/x/server/systems/config"
 	"go-home.io/x/server/systems/fanout"
 	"go-home.io/x/server/systems/logger"
+	"go-home.io/x/server/systems/metadata"
 	"go-home.io/x/server/systems/secret"
 	"go-home.io/x/server/systems/security"
+	"go-home.io/x/server/systems/snapshot"
 	"go-home.io/x/server/systems/storage"
 	"go-home.io/x/server/utils"
 	"gopkg.in/yaml.v2"
@@ -33,6 +35,8 @@ const (
 	configGoHomeMaster = "master"
 	// Describes config record for worker.
 	configGoHomeWorker = "worker"
+	// Describes config record for template device.
+	configTemplateDevice = "template"
 	// ConfigSelectorName describes selector name field.
 	ConfigSelectorName = "name"
 )
@@ -66,6 +70,8 @@ type settingsProvider struct {
 	validator    providers.IValidatorProvider
 	secrets      common.ISecretProvider
 	storage      providers.IStorageProvider
+	metadata     providers.IMetadataProvider
+	snapshots    providers.ISnapshotProvider
 
 	wSettings *providers.WorkerSettings
 	mSettings *providers.MasterSettings
@@ -81,11 +87,82 @@ type settingsProvider struct {
 	triggers     []*providers.RawMasterComponent
 	extendedAPIs []*providers.RawMasterComponent
 	groups       []*providers.RawMasterComponent
+	templates    []*providers.RawMasterComponent
 }
 
 // Load system configuration.
 func Load(options *StartUpOptions) providers.ISettingsProvider {
-	settings := settingsProvider{
+	settings := newSettingsProvider(options)
+	allProviders := settings.loadConfig(options)
+	if nil == allProviders {
+		return nil
+	}
+
+	allProviders = settings.loadDevicesAndGoHomeDefinitions(allProviders)
+	if settings.isWorker && nil == settings.wSettings {
+		settings.logger.Fatal("Didn't get workers settings",
+			errors.New("config provider returned nothing"))
+	}
+
+	if !settings.isWorker && nil == settings.mSettings {
+		settings.logger.Fatal("Didn't get master settings",
+			errors.New("config provider returned nothing"))
+	}
+
+	allProviders = settings.loadLoggerProvider(allProviders)
+
+	for _, v := range allProviders {
+		settings.parseProvider(v)
+	}
+
+	secConstruct := &security.ConstructSecurityProvider{
+		Roles:        settings.rawRoles,
+		Secret:       settings.secrets,
+		Loader:       settings.pluginLoader,
+		PluginLogger: settings.pluginLogger,
+		UserProvider: "",
+	}
+
+	if nil != settings.rawUsersProvider {
+		secConstruct.UserProvider = settings.rawUsersProvider.Provider
+		secConstruct.UserRawConfig = settings.rawUsersProvider.Config
+	}
+
+	settings.securityProvider = security.NewSecurityProvider(secConstruct)
+
+	settings.validate()
+	return settings
+}
+
+// LoadServiceBus loads only service bus configuration.
+// Used by utilities which need a bus access without starting a node.
+func LoadServiceBus(options *StartUpOptions, nodeID string) (providers.IBusProvider, common.ILoggerProvider) {
+	settings := newSettingsProvider(options)
+	settings.nodeID = nodeID
+
+	allProviders := settings.loadConfig(options)
+	if nil == allProviders {
+		return nil, settings.logger
+	}
+
+	allProviders = settings.loadLoggerProvider(allProviders)
+	for _, v := range allProviders {
+		if v.System == systems.SysBus.String() {
+			settings.parseProvider(v)
+		}
+	}
+
+	if nil == settings.bus {
+		settings.logger.Fatal("Didn't get service bus settings",
+			errors.New("config provider returned nothing"))
+	}
+
+	return settings.bus, settings.logger
+}
+
+// Creates a new settings provider with basic system providers.
+func newSettingsProvider(options *StartUpOptions) *settingsProvider {
+	settings := &settingsProvider{
 		isWorker:      options.IsWorker,
 		devicesConfig: make([]*providers.RawDevice, 0),
 		logger:        logger.NewConsoleLogger(),
@@ -95,6 +172,7 @@ func Load(options *StartUpOptions) providers.ISettingsProvider {
 		extendedAPIs:  make([]*providers.RawMasterComponent, 0),
 		fanOut:        fanout.NewFanOut(),
 		groups:        make([]*providers.RawMasterComponent, 0),
+		templates:     make([]*providers.RawMasterComponent, 0),
 	}
 
 	settings.validator = utils.NewValidator(settings.logger)
@@ -113,67 +191,39 @@ func Load(options *StartUpOptions) providers.ISettingsProvider {
 	}
 	settings.secrets = secret.NewSecretProvider(secretsConstruct)
 
+	return settings
+}
+
+// Loads all raw config records.
+func (s *settingsProvider) loadConfig(options *StartUpOptions) []*rawProvider {
 	tplCtor := &constructTemplate{
-		Logger:  settings.logger,
-		Secrets: settings.secrets,
+		Logger:  s.logger,
+		Secrets: s.secrets,
 	}
 	templateProvider := newTemplateProvider(tplCtor)
 
 	allProviders := make([]*rawProvider, 0)
 
 	cfgConstruct := &config.ConstructConfig{
-		PluginLogger: settings.pluginLogger,
+		PluginLogger: s.pluginLogger,
 		Options:      options.Config,
-		Loader:       settings.pluginLoader,
-		Secret:       settings.secrets,
+		Loader:       s.pluginLoader,
+		Secret:       s.secrets,
 	}
 	configProvider := config.NewConfigProvider(cfgConstruct)
 
 	dataChan := configProvider.Load()
 	if nil == dataChan {
-		settings.logger.Fatal("Didn't get any configuration",
+		s.logger.Fatal("Didn't get any configuration",
 			errors.New("config provider returned nothing"))
 		return nil
 	}
 
 	for fileData := range dataChan {
-		allProviders = append(allProviders, settings.loadFile(fileData, templateProvider)...)
+		allProviders = append(allProviders, s.loadFile(fileData, templateProvider)...)
 	}
 
-	allProviders = settings.loadDevicesAndGoHomeDefinitions(allProviders)
-	if settings.isWorker && nil == settings.wSettings {
-		settings.logger.Fatal("Didn't get workers settings",
-			errors.New("config provider returned nothing"))
-	}
-
-	if !settings.isWorker && nil == settings.mSettings {
-		settings.logger.Fatal("Didn't get master settings",
-			errors.New("config provider returned nothing"))
-	}
-
-	allProviders = settings.loadLoggerProvider(allProviders)
-
-	for _, v := range allProviders {
-		settings.parseProvider(v)
-	}
-
-	secConstruct := &security.ConstructSecurityProvider{
-		Roles:        settings.rawRoles,
-		Secret:       settings.secrets,
-		Loader:       settings.pluginLoader,
-		PluginLogger: settings.pluginLogger,
-		UserProvider: "",
-	}
-
-	if nil != settings.rawUsersProvider {
-		secConstruct.UserProvider = settings.rawUsersProvider.Provider
-		secConstruct.UserRawConfig = settings.rawUsersProvider.Config
-	}
-
-	settings.securityProvider = security.NewSecurityProvider(secConstruct)
-
-	settings.validate()
-	return &settings
+	return allProviders
 }
 
 // Validates whether all necessary settings are present.
@@ -213,6 +263,9 @@ func (s *settingsProvider) validate() {
 			s.storage = storage.NewEmptyStorageProvider()
 		}
 	}
+
+	s.loadMetadata()
+	s.loadSnapshots()
 }
 
 // Processes single yaml file.
@@ -377,6 +430,16 @@ func (s *settingsProvider) loadDeviceProvider(provider *rawProvider) (*providers
 		return nil, nil
 	}
 
+	if provider.Provider == configTemplateDevice {
+		s.templates = append(s.templates, &providers.RawMasterComponent{
+			Provider:  provider.Provider,
+			Name:      selector.Name,
+			RawConfig: provider.Config,
+		})
+
+		return nil, nil
+	}
+
 	deviceType := utils.VerifyDeviceProvider(provider.Provider)
 	if deviceType == enums.DevUnknown && provider.System != systems.SysAPI.String() {
 		s.logger.Warn("Ignoring device since type is unknown", common.LogDeviceTypeToken, provider.Provider,
@@ -548,6 +611,47 @@ func (s *settingsProvider) processStorage(provider *rawProvider) {
 	s.storage = storage.NewStorageProvider(ctor)
 }
 
+// Loads devices metadata.
+// Metadata is persisted by master only.
+func (s *settingsProvider) loadMetadata() {
+	if s.isWorker {
+		s.metadata = metadata.NewEmptyMetadataProvider()
+		return
+	}
+
+	location := s.mSettings.Metadata
+	if "" == location {
+		location = fmt.Sprintf("%s/_metadata.yaml", utils.GetDefaultConfigsDir())
+	}
+
+	s.metadata = metadata.NewMetadataProvider(&metadata.ConstructMetadata{
+		Logger:   s.logger,
+		FanOut:   s.fanOut,
+		Location: location,
+	})
+}
+
+// Loads camera snapshots archive.
+// Snapshots are persisted by master only.
+func (s *settingsProvider) loadSnapshots() {
+	if s.isWorker || !s.mSettings.Snapshots.Enabled {
+		s.snapshots = snapshot.NewEmptySnapshotProvider()
+		return
+	}
+
+	location := s.mSettings.Snapshots.Location
+	if "" == location {
+		location = fmt.Sprintf("%s/_snapshots", utils.GetDefaultConfigsDir())
+	}
+
+	s.snapshots = snapshot.NewSnapshotProvider(&snapshot.ConstructSnapshot{
+		Logger:   s.logger,
+		Location: location,
+		MaxCount: s.mSettings.Snapshots.MaxCount,
+		MaxAge:   s.mSettings.Snapshots.MaxAge,
+	})
+}
+
 // Processes security groups.
 func (s *settingsProvider) processSecurity(provider *rawProvider) {
 	if s.isWorker {
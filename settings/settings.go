@@ -89,7 +89,22 @@ func (s *settingsProvider) Groups() []*providers.RawMasterComponent {
 	return s.groups
 }
 
+// Templates returns a list of known template devices.
+func (s *settingsProvider) Templates() []*providers.RawMasterComponent {
+	return s.templates
+}
+
 // Storage returns a storage provider.
 func (s *settingsProvider) Storage() providers.IStorageProvider {
 	return s.storage
 }
+
+// Metadata returns devices metadata provider.
+func (s *settingsProvider) Metadata() providers.IMetadataProvider {
+	return s.metadata
+}
+
+// Snapshots returns camera snapshots provider.
+func (s *settingsProvider) Snapshots() providers.ISnapshotProvider {
+	return s.snapshots
+}
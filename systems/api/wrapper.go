@@ -149,7 +149,7 @@ func (p *provider) Subscribe(queue chan []byte) error {
 func (p *provider) Publish(messages ...api.IExtendedAPIMessage) {
 	msgs := make([]interface{}, 0)
 	for _, v := range messages {
-		v.SetSendTime(utils.TimeNow())
+		v.SetSendTime(utils.BusTimeNow())
 		msgs = append(msgs, v)
 	}
 	p.serviceBus.PublishStr(p.outChannelName, msgs...)
@@ -175,7 +175,7 @@ func (p *provider) busCycle() {
 			continue
 		}
 
-		if utils.TimeNow()-pluginMsg.SendTime > bus.MsgTTLSeconds {
+		if utils.BusTimeNow()-pluginMsg.SendTime > bus.MsgTTLSeconds {
 			p.logger.Debug("Received API message is too old")
 			continue
 		}
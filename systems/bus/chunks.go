@@ -0,0 +1,190 @@
+package bus
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go-home.io/x/server/plugins/bus"
+	"go-home.io/x/server/plugins/common"
+)
+
+const (
+	// Default maximum size of a single bus message in bytes.
+	defaultMaxMessageSize = 256 * 1024
+	// Minimum allowed size of a single bus message in bytes.
+	minMaxMessageSize = 1024
+	// Default timeout for incomplete chunks set.
+	defaultChunkTimeout = 30 * time.Second
+	// Reserved space for chunk message fields.
+	chunkOverhead = 256
+	// Maximum number of chunks in a single set.
+	maxChunks = 4096
+)
+
+// Chunking settings, loaded from the service bus config.
+type chunkSettings struct {
+	MaxMessageSize int `yaml:"maxMessageSize"`
+	ChunkTimeout   int `yaml:"chunkTimeout"`
+}
+
+// Splits large messages into chunks.
+type chunker struct {
+	nodeID         string
+	maxMessageSize int
+	counter        uint64
+}
+
+// Partially received message.
+type chunksSet struct {
+	parts    [][]byte
+	received int
+	checksum string
+	started  time.Time
+}
+
+// Re-assembles chunked messages received from a single channel.
+type assembler struct {
+	logger  common.ILoggerProvider
+	channel string
+	timeout time.Duration
+	sets    map[string]*chunksSet
+}
+
+// Helper type for detecting chunks.
+type chunkHeader struct {
+	Type bus.MessageType `json:"mt"`
+}
+
+// Constructs a new chunker.
+func newChunker(nodeID string, maxMessageSize int) *chunker {
+	if maxMessageSize <= 0 {
+		maxMessageSize = defaultMaxMessageSize
+	}
+
+	if maxMessageSize < minMaxMessageSize {
+		maxMessageSize = minMaxMessageSize
+	}
+
+	return &chunker{
+		nodeID:         nodeID,
+		maxMessageSize: maxMessageSize,
+	}
+}
+
+// Splits message into chunks, if it exceeds max message size.
+// Chunk data is base64 encoded, so it takes 4/3 of the original size.
+func (c *chunker) split(body []byte) []interface{} {
+	if len(body) <= c.maxMessageSize {
+		return []interface{}{json.RawMessage(body)}
+	}
+
+	size := (c.maxMessageSize - chunkOverhead) * 3 / 4
+	total := (len(body) + size - 1) / size
+	sum := sha256.Sum256(body)
+	checksum := hex.EncodeToString(sum[:])
+	chunkID := fmt.Sprintf("%s-%d", c.nodeID, atomic.AddUint64(&c.counter, 1))
+
+	res := make([]interface{}, 0, total)
+	for ii := 0; ii < total; ii++ {
+		end := (ii + 1) * size
+		if end > len(body) {
+			end = len(body)
+		}
+
+		res = append(res, NewChunkMessage(chunkID, ii, total, checksum, body[ii*size:end]))
+	}
+
+	return res
+}
+
+// Constructs a new assembler.
+func newAssembler(logger common.ILoggerProvider, channel string, timeout time.Duration) *assembler {
+	if timeout <= 0 {
+		timeout = defaultChunkTimeout
+	}
+
+	return &assembler{
+		logger:  logger,
+		channel: channel,
+		timeout: timeout,
+		sets:    make(map[string]*chunksSet),
+	}
+}
+
+// Processes incoming message.
+// Returns message which should be passed further, if any.
+func (a *assembler) process(msg bus.RawMessage) (bus.RawMessage, bool) {
+	h := &chunkHeader{}
+	err := json.Unmarshal(msg.Body, h)
+	if err != nil || bus.MsgChunk != h.Type {
+		return msg, true
+	}
+
+	c := &ChunkMessage{}
+	err = json.Unmarshal(msg.Body, c)
+	if err != nil || c.Total <= 0 || c.Total > maxChunks || c.Index < 0 || c.Index >= c.Total {
+		a.logger.Warn("Received corrupted chunk", common.LogSystemToken, logSystem,
+			common.LogChannelToken, a.channel)
+		return msg, false
+	}
+
+	set, ok := a.sets[c.ChunkID]
+	if !ok {
+		set = &chunksSet{
+			parts:    make([][]byte, c.Total),
+			checksum: c.Checksum,
+			started:  time.Now(),
+		}
+		a.sets[c.ChunkID] = set
+	}
+
+	if len(set.parts) != c.Total || set.checksum != c.Checksum {
+		a.logger.Warn("Received inconsistent chunk", common.LogSystemToken, logSystem,
+			common.LogChannelToken, a.channel, "chunk", c.ChunkID)
+		delete(a.sets, c.ChunkID)
+		return msg, false
+	}
+
+	if nil != set.parts[c.Index] {
+		return msg, false
+	}
+
+	set.parts[c.Index] = c.Data
+	set.received++
+	if set.received < c.Total {
+		return msg, false
+	}
+
+	delete(a.sets, c.ChunkID)
+	body := make([]byte, 0)
+	for _, v := range set.parts {
+		body = append(body, v...)
+	}
+
+	sum := sha256.Sum256(body)
+	if hex.EncodeToString(sum[:]) != set.checksum {
+		a.logger.Warn("Chunked message failed integrity check", common.LogSystemToken, logSystem,
+			common.LogChannelToken, a.channel, "chunk", c.ChunkID)
+		return msg, false
+	}
+
+	return bus.RawMessage{Body: body}, true
+}
+
+// Removes incomplete sets which were not finished in time.
+func (a *assembler) cleanup() {
+	for k, v := range a.sets {
+		if time.Since(v.started) < a.timeout {
+			continue
+		}
+
+		a.logger.Warn("Chunked message was not completed in time", common.LogSystemToken, logSystem,
+			common.LogChannelToken, a.channel, "chunk", k,
+			"received", fmt.Sprintf("%d/%d", v.received, len(v.parts)))
+		delete(a.sets, k)
+	}
+}
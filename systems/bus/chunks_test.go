@@ -0,0 +1,184 @@
+package bus
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/bus"
+)
+
+// Fake bus plugin which delivers published messages to subscribers.
+type loopbackPlugin struct {
+	fakePlugin
+	queues map[string]chan bus.RawMessage
+	sent   int
+}
+
+func (f *loopbackPlugin) Subscribe(channel string, queue chan bus.RawMessage) error {
+	f.queues[channel] = queue
+	return nil
+}
+
+func (f *loopbackPlugin) Publish(channel string, messages ...interface{}) {
+	for _, v := range messages {
+		f.sent++
+		data, _ := json.Marshal(v) // nolint: gosec
+		f.queues[channel] <- bus.RawMessage{Body: data}
+	}
+}
+
+// Converts chunks into raw messages.
+func toRaw(t *testing.T, messages []interface{}) []bus.RawMessage {
+	res := make([]bus.RawMessage, 0)
+	for _, v := range messages {
+		data, err := json.Marshal(v)
+		require.NoError(t, err, "marshal")
+		res = append(res, bus.RawMessage{Body: data})
+	}
+
+	return res
+}
+
+// Returns a large message body.
+func getLargeBody(t *testing.T) []byte {
+	body, err := json.Marshal(NewDeviceCommandMessage("test", 0, map[string]interface{}{
+		"picture": strings.Repeat("abcdefgh", 1000),
+	}))
+	require.NoError(t, err, "marshal")
+	return body
+}
+
+// Tests that small messages are not split.
+func TestChunkerSmallMessage(t *testing.T) {
+	c := newChunker("test", 0)
+	res := c.split([]byte(`{"mt":1}`))
+	require.Equal(t, 1, len(res), "chunks")
+	assert.Equal(t, json.RawMessage(`{"mt":1}`), res[0], "body")
+}
+
+// Tests that chunks don't exceed max message size and could be re-assembled in any order.
+func TestChunksReassembly(t *testing.T) {
+	body := getLargeBody(t)
+	c := newChunker("test", minMaxMessageSize)
+	chunks := toRaw(t, c.split(body))
+	require.True(t, len(chunks) > 1, "not split")
+
+	a := newAssembler(mocks.FakeNewLogger(nil), "test", 0)
+	for ii := len(chunks) - 1; ii >= 0; ii-- {
+		assert.True(t, len(chunks[ii].Body) <= minMaxMessageSize, "chunk %d size", ii)
+
+		res, ok := a.process(chunks[ii])
+		if ii > 0 {
+			assert.False(t, ok, "chunk %d passed", ii)
+			continue
+		}
+
+		require.True(t, ok, "not assembled")
+		assert.Equal(t, body, res.Body, "body")
+	}
+
+	assert.Equal(t, 0, len(a.sets), "sets")
+}
+
+// Tests that corrupted chunks are discarded.
+func TestChunksIntegrity(t *testing.T) {
+	body := getLargeBody(t)
+	c := newChunker("test", minMaxMessageSize)
+	messages := c.split(body)
+	messages[1].(*ChunkMessage).Data[0] = 'z'
+
+	logged := false
+	a := newAssembler(mocks.FakeNewLogger(func(string) {
+		logged = true
+	}), "test", 0)
+
+	for _, v := range toRaw(t, messages) {
+		_, ok := a.process(v)
+		assert.False(t, ok, "passed")
+	}
+
+	assert.True(t, logged, "not logged")
+	assert.Equal(t, 0, len(a.sets), "sets")
+}
+
+// Tests that chunks with invalid count are discarded.
+func TestChunksBounds(t *testing.T) {
+	logged := 0
+	a := newAssembler(mocks.FakeNewLogger(func(string) {
+		logged++
+	}), "test", 0)
+
+	messages := []interface{}{
+		NewChunkMessage("test-1", 0, maxChunks+1, "sum", []byte("data")),
+		NewChunkMessage("test-2", 0, 0, "sum", []byte("data")),
+		NewChunkMessage("test-3", 2, 2, "sum", []byte("data")),
+		NewChunkMessage("test-4", -1, 2, "sum", []byte("data")),
+	}
+
+	for _, v := range toRaw(t, messages) {
+		_, ok := a.process(v)
+		assert.False(t, ok, "passed")
+	}
+
+	assert.Equal(t, len(messages), logged, "not logged")
+	assert.Equal(t, 0, len(a.sets), "sets")
+}
+
+// Tests that incomplete sets are removed after timeout.
+func TestChunksTimeout(t *testing.T) {
+	c := newChunker("test", minMaxMessageSize)
+	chunks := toRaw(t, c.split(getLargeBody(t)))
+
+	a := newAssembler(mocks.FakeNewLogger(nil), "test", 100*time.Millisecond)
+	a.process(chunks[0])
+	a.cleanup()
+	assert.Equal(t, 1, len(a.sets), "removed too early")
+
+	time.Sleep(150 * time.Millisecond)
+	a.cleanup()
+	assert.Equal(t, 0, len(a.sets), "not removed")
+
+	_, ok := a.process(chunks[1])
+	assert.False(t, ok, "passed")
+}
+
+// Tests transparent chunking through the service bus provider.
+func TestServiceBusChunking(t *testing.T) {
+	f := &loopbackPlugin{queues: make(map[string]chan bus.RawMessage)}
+	p, err := NewServiceBusProvider(&ConstructBus{
+		Loader:    mocks.FakeNewPluginLoader(f),
+		Logger:    mocks.FakeNewLogger(nil),
+		RawConfig: []byte("maxMessageSize: 2048\nchunkTimeout: 5"),
+	})
+	require.NoError(t, err, "load")
+
+	queue := make(chan bus.RawMessage, 10)
+	require.NoError(t, p.SubscribeStr("test", queue), "subscribe")
+
+	body := getLargeBody(t)
+	p.PublishStr("test", json.RawMessage(body), NewDeviceResyncMessage("test"))
+	assert.True(t, f.sent > 2, "not split")
+
+	select {
+	case msg := <-queue:
+		assert.Equal(t, body, msg.Body, "large message")
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "large message was not received")
+	}
+
+	select {
+	case msg := <-queue:
+		m := &DeviceResyncMessage{}
+		require.NoError(t, json.Unmarshal(msg.Body, m), "unmarshal")
+		assert.Equal(t, bus.MsgDeviceResync, m.Type, "small message")
+	case <-time.After(1 * time.Second):
+		require.Fail(t, "small message was not received")
+	}
+
+	p.Unsubscribe("test")
+}
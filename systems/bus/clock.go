@@ -0,0 +1,99 @@
+package bus
+
+import (
+	"sync"
+
+	"go-home.io/x/server/utils"
+)
+
+const (
+	// Maximum round-trip time for a clock sample to be taken into account.
+	clockMaxRoundTripMs = 5000
+	// Number of recent clock samples and pings to keep.
+	clockHistorySize = 8
+	// ClockSkewWarningMs describes clock skew which is considered as a large one.
+	ClockSkewWarningMs = 2000
+)
+
+// Single clock offset measurement.
+type clockSample struct {
+	offset    int64
+	roundTrip int64
+}
+
+// Estimates offset between local and master clocks using discovery round-trips.
+type clockEstimator struct {
+	sync.Mutex
+	pings   []int64
+	samples []*clockSample
+}
+
+// Worker's clock estimator.
+var busClock = &clockEstimator{
+	pings:   make([]int64, 0),
+	samples: make([]*clockSample, 0),
+}
+
+// Registers local time of the sent discovery message.
+func (c *clockEstimator) registerPing(pingTime int64) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.pings = append(c.pings, pingTime)
+	if len(c.pings) > clockHistorySize {
+		c.pings = c.pings[1:]
+	}
+}
+
+// Checks whether ping time belongs to one of the recently sent discovery messages.
+func (c *clockEstimator) isRecentPing(pingTime int64) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, v := range c.pings {
+		if v == pingTime {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Processes master's reply to the discovery message.
+// Sample with the shortest round-trip gives the most accurate estimation.
+func (c *clockEstimator) processReply(pingTime int64, masterTime int64, receiveTime int64) {
+	if 0 == pingTime || 0 == masterTime || !c.isRecentPing(pingTime) {
+		return
+	}
+
+	roundTrip := receiveTime - pingTime
+	if roundTrip < 0 || roundTrip > clockMaxRoundTripMs {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.samples = append(c.samples, &clockSample{
+		offset:    masterTime - (pingTime+receiveTime)/2,
+		roundTrip: roundTrip,
+	})
+
+	if len(c.samples) > clockHistorySize {
+		c.samples = c.samples[1:]
+	}
+
+	best := c.samples[0]
+	for _, v := range c.samples {
+		if v.roundTrip < best.roundTrip {
+			best = v
+		}
+	}
+
+	utils.SetBusClockOffset(best.offset)
+}
+
+// IsLargeClockSkew checks whether clock skew is large enough to warn about it.
+func IsLargeClockSkew(skewMs int64) bool {
+	return skewMs > ClockSkewWarningMs || skewMs < -ClockSkewWarningMs
+}
@@ -0,0 +1,40 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-home.io/x/server/utils"
+)
+
+// Tests clock offset estimation.
+func TestClockOffsetEstimation(t *testing.T) {
+	defer utils.SetBusClockOffset(0)
+	c := &clockEstimator{}
+
+	c.registerPing(1000)
+	c.registerPing(2000)
+
+	c.processReply(1000, 61200, 1400)
+	assert.Equal(t, int64(60000), utils.GetBusClockOffset(), "first sample")
+
+	c.processReply(2000, 62100, 2100)
+	assert.Equal(t, int64(60050), utils.GetBusClockOffset(), "shortest round-trip")
+
+	utils.SetBusClockOffset(0)
+	c.processReply(3000, 63000, 3100)
+	assert.Equal(t, int64(0), utils.GetBusClockOffset(), "unknown ping")
+
+	c.registerPing(4000)
+	c.processReply(4000, 70000, 4000+clockMaxRoundTripMs+1)
+	assert.Equal(t, int64(0), utils.GetBusClockOffset(), "long round-trip")
+}
+
+// Tests that bus time is adjusted by the offset.
+func TestBusTimeNow(t *testing.T) {
+	defer utils.SetBusClockOffset(0)
+	utils.SetBusClockOffset(-3600 * 1000)
+	assert.InDelta(t, utils.TimeNow()-3600, utils.BusTimeNow(), 1)
+	assert.True(t, IsLargeClockSkew(utils.GetBusClockOffset()))
+	assert.False(t, IsLargeClockSkew(ClockSkewWarningMs))
+}
@@ -5,6 +5,7 @@ import (
 
 	"go-home.io/x/server/plugins/bus"
 	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/utils"
 )
 
 // IMessageParserProvider describes messages parser.
@@ -19,6 +20,8 @@ type IMasterMessageParserProvider interface {
 	GetDiscoveryMessageChan() chan *DiscoveryMessage
 	GetDeviceUpdateMessageChan() chan *DeviceUpdateMessage
 	GetEntityLoadStatueMessageChan() chan *EntityLoadStatusMessage
+	GetDeviceRemovedMessageChan() chan *DeviceRemovedMessage
+	GetDeviceEventMessageChan() chan *DeviceEventMessage
 }
 
 // IWorkerMessageParserProvider describes messages parser for worker.
@@ -27,6 +30,7 @@ type IWorkerMessageParserProvider interface {
 
 	GetDeviceAssignmentMessageChan() chan *DeviceAssignmentMessage
 	GetDeviceCommandMessageChan() chan *DeviceCommandMessage
+	GetDeviceResyncMessageChan() chan *DeviceResyncMessage
 }
 
 // Message parser implementation.
@@ -36,10 +40,13 @@ type messageParser struct {
 
 	deviceAssignmentChan chan *DeviceAssignmentMessage
 	deviceCommandsChan   chan *DeviceCommandMessage
+	deviceResyncChan     chan *DeviceResyncMessage
 
 	discoveryMessageChan        chan *DiscoveryMessage
 	deviceUpdateMessageChan     chan *DeviceUpdateMessage
 	entityLoadStatusMessageChan chan *EntityLoadStatusMessage
+	deviceRemovedMessageChan    chan *DeviceRemovedMessage
+	deviceEventMessageChan      chan *DeviceEventMessage
 }
 
 // NewWorkerMessageParser constructs parser for worker.
@@ -48,6 +55,7 @@ func NewWorkerMessageParser(logger common.ILoggerProvider) IWorkerMessageParserP
 		logger:               logger,
 		deviceAssignmentChan: make(chan *DeviceAssignmentMessage, 5),
 		deviceCommandsChan:   make(chan *DeviceCommandMessage, 20),
+		deviceResyncChan:     make(chan *DeviceResyncMessage, 20),
 		isWorker:             true,
 	}
 }
@@ -59,6 +67,8 @@ func NewMasterMessageParser(logger common.ILoggerProvider) IMasterMessageParserP
 		discoveryMessageChan:        make(chan *DiscoveryMessage, 5),
 		deviceUpdateMessageChan:     make(chan *DeviceUpdateMessage, 50),
 		entityLoadStatusMessageChan: make(chan *EntityLoadStatusMessage, 50),
+		deviceRemovedMessageChan:    make(chan *DeviceRemovedMessage, 20),
+		deviceEventMessageChan:      make(chan *DeviceEventMessage, 50),
 		isWorker:                    false,
 	}
 }
@@ -73,6 +83,11 @@ func (w *messageParser) GetDeviceCommandMessageChan() chan *DeviceCommandMessage
 	return w.deviceCommandsChan
 }
 
+// GetDeviceResyncMessageChan returns channel used for device resync callbacks.
+func (w *messageParser) GetDeviceResyncMessageChan() chan *DeviceResyncMessage {
+	return w.deviceResyncChan
+}
+
 // GetDiscoveryMessageChan returns channel used for discovery callbacks.
 func (w *messageParser) GetDiscoveryMessageChan() chan *DiscoveryMessage {
 	return w.discoveryMessageChan
@@ -87,8 +102,19 @@ func (w *messageParser) GetEntityLoadStatueMessageChan() chan *EntityLoadStatusM
 	return w.entityLoadStatusMessageChan
 }
 
+// GetDeviceRemovedMessageChan returns channel used for device removal callbacks.
+func (w *messageParser) GetDeviceRemovedMessageChan() chan *DeviceRemovedMessage {
+	return w.deviceRemovedMessageChan
+}
+
+// GetDeviceEventMessageChan returns channel used for device events callbacks.
+func (w *messageParser) GetDeviceEventMessageChan() chan *DeviceEventMessage {
+	return w.deviceEventMessageChan
+}
+
 // ProcessIncomingMessage parses incoming service bus message.
 func (w *messageParser) ProcessIncomingMessage(r *bus.RawMessage) {
+	receiveTime := utils.TimeNowMs()
 	var err error
 	b, err := parseRawMessage(r)
 	if err != nil {
@@ -97,7 +123,7 @@ func (w *messageParser) ProcessIncomingMessage(r *bus.RawMessage) {
 	}
 
 	if w.isWorker {
-		err = w.processWorkerMessage(b, r)
+		err = w.processWorkerMessage(b, r, receiveTime)
 	} else {
 		err = w.processServerMessage(b, r)
 	}
@@ -110,13 +136,14 @@ func (w *messageParser) ProcessIncomingMessage(r *bus.RawMessage) {
 
 // Processes worker messages.
 // nolint: dupl
-func (w *messageParser) processWorkerMessage(b *MessageWithType, r *bus.RawMessage) error {
+func (w *messageParser) processWorkerMessage(b *MessageWithType, r *bus.RawMessage, receiveTime int64) error {
 	var err error
 	switch b.Type {
 	case bus.MsgDeviceAssignment:
 		var d DeviceAssignmentMessage
 		err = json.Unmarshal(r.Body, &d)
 		if err == nil {
+			busClock.processReply(d.PingTime, d.MasterTime, receiveTime)
 			w.deviceAssignmentChan <- &d
 		}
 	case bus.MsgDeviceCommand:
@@ -125,6 +152,12 @@ func (w *messageParser) processWorkerMessage(b *MessageWithType, r *bus.RawMessa
 		if err == nil {
 			w.deviceCommandsChan <- &d
 		}
+	case bus.MsgDeviceResync:
+		var d DeviceResyncMessage
+		err = json.Unmarshal(r.Body, &d)
+		if err == nil {
+			w.deviceResyncChan <- &d
+		}
 	default:
 		w.logger.Warn("Received unknown message type", "type", b.Type.String(),
 			common.LogSystemToken, logSystem)
@@ -158,6 +191,18 @@ func (w *messageParser) processServerMessage(b *MessageWithType, r *bus.RawMessa
 		if err == nil {
 			w.entityLoadStatusMessageChan <- &m
 		}
+	case bus.MsgDeviceRemoved:
+		var m DeviceRemovedMessage
+		err := json.Unmarshal(r.Body, &m)
+		if err == nil {
+			w.deviceRemovedMessageChan <- &m
+		}
+	case bus.MsgDeviceEvent:
+		var m DeviceEventMessage
+		err := json.Unmarshal(r.Body, &m)
+		if err == nil {
+			w.deviceEventMessageChan <- &m
+		}
 	default:
 		w.logger.Warn("Received unknown message type", "type", b.Type.String(),
 			common.LogSystemToken, logSystem)
@@ -17,6 +17,8 @@ func TestMasterServerParser(t *testing.T) {
 	disco := false
 	upd := false
 	load := false
+	removed := false
+	event := false
 
 	go func() {
 		for {
@@ -27,16 +29,22 @@ func TestMasterServerParser(t *testing.T) {
 				upd = true
 			case <-p.GetEntityLoadStatueMessageChan():
 				load = true
+			case <-p.GetDeviceRemovedMessageChan():
+				removed = true
+			case <-p.GetDeviceEventMessageChan():
+				event = true
 			}
 		}
 	}()
 
 	data := []struct {
-		msg   string
-		disco bool
-		upd   bool
-		load  bool
-		err   string
+		msg     string
+		disco   bool
+		upd     bool
+		load    bool
+		removed bool
+		event   bool
+		err     string
 	}{
 		{
 			msg:   fmt.Sprintf(`{"mt": "ping",  "st": %d}`, utils.TimeNow()),
@@ -73,17 +81,31 @@ func TestMasterServerParser(t *testing.T) {
 			load:  true,
 			err:   "entity load",
 		},
+		{
+			msg:     fmt.Sprintf(`{"mt": "device_removed",  "st": %d}`, utils.TimeNow()),
+			removed: true,
+			err:     "device removed",
+		},
+		{
+			msg:   fmt.Sprintf(`{"mt": "device_event",  "st": %d, "e": "double-click"}`, utils.TimeNow()),
+			event: true,
+			err:   "device event",
+		},
 	}
 
 	for _, v := range data {
 		disco = false
 		upd = false
 		load = false
+		removed = false
+		event = false
 		p.ProcessIncomingMessage(&bus.RawMessage{Body: []byte(v.msg)})
 		time.Sleep(1 * time.Second)
 		assert.Equal(t, v.upd, upd, "update %s", v.err)
 		assert.Equal(t, v.disco, disco, "discovery %s", v.err)
 		assert.Equal(t, v.load, load, "load %s", v.err)
+		assert.Equal(t, v.removed, removed, "removed %s", v.err)
+		assert.Equal(t, v.event, event, "event %s", v.err)
 	}
 }
 
@@ -92,6 +114,7 @@ func TestWorkerServerParser(t *testing.T) {
 	p := NewWorkerMessageParser(mocks.FakeNewLogger(nil))
 	assign := false
 	cmd := false
+	resync := false
 
 	go func() {
 		for {
@@ -100,6 +123,8 @@ func TestWorkerServerParser(t *testing.T) {
 				assign = true
 			case <-p.GetDeviceCommandMessageChan():
 				cmd = true
+			case <-p.GetDeviceResyncMessageChan():
+				resync = true
 			}
 		}
 	}()
@@ -108,6 +133,7 @@ func TestWorkerServerParser(t *testing.T) {
 		msg    string
 		assign bool
 		cmd    bool
+		resync bool
 		err    string
 	}{
 		{
@@ -134,14 +160,21 @@ func TestWorkerServerParser(t *testing.T) {
 			cmd:    false,
 			err:    "old message",
 		},
+		{
+			msg:    fmt.Sprintf(`{"mt": "device_resync",  "st": %d}`, utils.TimeNow()),
+			resync: true,
+			err:    "device resync",
+		},
 	}
 
 	for _, v := range data {
 		assign = false
 		cmd = false
+		resync = false
 		p.ProcessIncomingMessage(&bus.RawMessage{Body: []byte(v.msg)})
 		time.Sleep(1 * time.Second)
 		assert.Equal(t, v.cmd, cmd, "command %s", v.err)
 		assert.Equal(t, v.assign, assign, "assignment %s", v.err)
+		assert.Equal(t, v.resync, resync, "resync %s", v.err)
 	}
 }
@@ -2,6 +2,7 @@ package bus
 
 import (
 	"go-home.io/x/server/plugins/bus"
+	"go-home.io/x/server/plugins/device"
 	"go-home.io/x/server/plugins/device/enums"
 	"go-home.io/x/server/utils"
 )
@@ -25,6 +26,8 @@ type DiscoveryMessage struct {
 	Properties   map[string]string `json:"p"`
 	IsFirstStart bool              `json:"f"`
 	MaxDevices   int               `json:"m"`
+	PingTime     int64             `json:"pt"`
+	BusQueue     *QueueStats       `json:"q,omitempty"`
 }
 
 // DeviceAssignment type with single device assignment.
@@ -42,8 +45,10 @@ type DeviceAssignment struct {
 // DeviceAssignmentMessage used by server to send a new set of devices to worker.
 type DeviceAssignmentMessage struct {
 	MessageWithType
-	Devices []*DeviceAssignment `json:"d"`
-	UOM     enums.UOM           `json:"u"`
+	Devices    []*DeviceAssignment `json:"d"`
+	UOM        enums.UOM           `json:"u"`
+	PingTime   int64               `json:"pt"`
+	MasterTime int64               `json:"ct"`
 }
 
 // EntityLoadStatusMessage used by worker to notify master about entity load status.
@@ -55,14 +60,21 @@ type EntityLoadStatusMessage struct {
 }
 
 // DeviceUpdateMessage used by worker to update service with devices state update.
+// Delta messages contain only changed properties and no commands.
+// Unavailable flag is set when worker can't reach the device.
+// Capabilities are sent only with full state messages.
 type DeviceUpdateMessage struct {
 	MessageWithType
-	DeviceType enums.DeviceType       `json:"t"`
-	DeviceID   string                 `json:"i"`
-	State      map[string]interface{} `json:"s"`
-	Commands   []string               `json:"o"`
-	WorkerID   string                 `json:"w"`
-	DeviceName string                 `json:"n"`
+	DeviceType   enums.DeviceType       `json:"t"`
+	DeviceID     string                 `json:"i"`
+	State        map[string]interface{} `json:"s"`
+	Commands     []string               `json:"o"`
+	WorkerID     string                 `json:"w"`
+	DeviceName   string                 `json:"n"`
+	Version      uint64                 `json:"v"`
+	IsDelta      bool                   `json:"d"`
+	Unavailable  bool                   `json:"u,omitempty"`
+	Capabilities *device.Capabilities   `json:"c,omitempty"`
 }
 
 // DeviceCommandMessage used by server to invoke device command on a worker.
@@ -73,20 +85,57 @@ type DeviceCommandMessage struct {
 	Payload  map[string]interface{} `json:"p"`
 }
 
+// DeviceResyncMessage used by server to request device's full state from a worker.
+type DeviceResyncMessage struct {
+	MessageWithType
+	DeviceID string `json:"i"`
+}
+
+// DeviceRemovedMessage used by worker to notify master about device, removed from the hub.
+type DeviceRemovedMessage struct {
+	MessageWithType
+	DeviceID string `json:"i"`
+	WorkerID string `json:"w"`
+}
+
+// DeviceEventMessage used by worker to notify master about momentary device event.
+type DeviceEventMessage struct {
+	MessageWithType
+	DeviceID string                 `json:"i"`
+	WorkerID string                 `json:"w"`
+	Event    enums.DeviceEvent      `json:"e"`
+	Data     map[string]interface{} `json:"p"`
+}
+
+// ChunkMessage used for transferring a part of the large message.
+// Checksum is calculated for the whole message.
+type ChunkMessage struct {
+	MessageWithType
+	ChunkID  string `json:"ci"`
+	Index    int    `json:"cx"`
+	Total    int    `json:"cn"`
+	Checksum string `json:"cs"`
+	Data     []byte `json:"cd"`
+}
+
 // NewDiscoveryMessage constructs discovery message.
+// Local send time is registered, so master's reply could be used for clock offset estimation.
 func NewDiscoveryMessage(nodeID string, firstStart bool, properties map[string]string,
 	maxDevices int) *DiscoveryMessage {
 	msg := DiscoveryMessage{
 		MessageWithType: MessageWithType{
 			Type:     bus.MsgPing,
-			SendTime: utils.TimeNow(),
+			SendTime: utils.BusTimeNow(),
 		},
 		NodeID:       nodeID,
 		IsFirstStart: firstStart,
 		Properties:   make(map[string]string, len(properties)),
 		MaxDevices:   maxDevices,
+		PingTime:     utils.TimeNowMs(),
 	}
 
+	busClock.registerPing(msg.PingTime)
+
 	for k, v := range properties {
 		msg.Properties[k] = v
 	}
@@ -99,10 +148,11 @@ func NewDeviceAssignmentMessage(devices []*DeviceAssignment, uom enums.UOM) *Dev
 	return &DeviceAssignmentMessage{
 		MessageWithType: MessageWithType{
 			Type:     bus.MsgDeviceAssignment,
-			SendTime: utils.TimeNow(),
+			SendTime: utils.BusTimeNow(),
 		},
-		Devices: devices,
-		UOM:     uom,
+		Devices:    devices,
+		UOM:        uom,
+		MasterTime: utils.TimeNowMs(),
 	}
 }
 
@@ -111,7 +161,7 @@ func NewDeviceUpdateMessage() *DeviceUpdateMessage {
 	return &DeviceUpdateMessage{
 		MessageWithType: MessageWithType{
 			Type:     bus.MsgDeviceUpdate,
-			SendTime: utils.TimeNow(),
+			SendTime: utils.BusTimeNow(),
 		},
 	}
 }
@@ -122,7 +172,7 @@ func NewDeviceCommandMessage(deviceID string, command enums.Command,
 	return &DeviceCommandMessage{
 		MessageWithType: MessageWithType{
 			Type:     bus.MsgDeviceCommand,
-			SendTime: utils.TimeNow(),
+			SendTime: utils.BusTimeNow(),
 		},
 		Command:  command,
 		Payload:  data,
@@ -135,10 +185,63 @@ func NewEntityLoadStatusMessage(entityName string, nodeID string, isSuccess bool
 	return &EntityLoadStatusMessage{
 		MessageWithType: MessageWithType{
 			Type:     bus.MsgEntityLoadStatus,
-			SendTime: utils.TimeNow(),
+			SendTime: utils.BusTimeNow(),
 		},
 		Name:      entityName,
 		IsSuccess: isSuccess,
 		NodeID:    nodeID,
 	}
 }
+
+// NewDeviceResyncMessage constructs device resync message.
+func NewDeviceResyncMessage(deviceID string) *DeviceResyncMessage {
+	return &DeviceResyncMessage{
+		MessageWithType: MessageWithType{
+			Type:     bus.MsgDeviceResync,
+			SendTime: utils.BusTimeNow(),
+		},
+		DeviceID: deviceID,
+	}
+}
+
+// NewDeviceRemovedMessage constructs device removed message.
+func NewDeviceRemovedMessage(deviceID string, workerID string) *DeviceRemovedMessage {
+	return &DeviceRemovedMessage{
+		MessageWithType: MessageWithType{
+			Type:     bus.MsgDeviceRemoved,
+			SendTime: utils.BusTimeNow(),
+		},
+		DeviceID: deviceID,
+		WorkerID: workerID,
+	}
+}
+
+// NewDeviceEventMessage constructs device event message.
+func NewDeviceEventMessage(deviceID string, workerID string, event enums.DeviceEvent,
+	data map[string]interface{}) *DeviceEventMessage {
+	return &DeviceEventMessage{
+		MessageWithType: MessageWithType{
+			Type:     bus.MsgDeviceEvent,
+			SendTime: utils.BusTimeNow(),
+		},
+		DeviceID: deviceID,
+		WorkerID: workerID,
+		Event:    event,
+		Data:     data,
+	}
+}
+
+// NewChunkMessage constructs a single chunk message.
+func NewChunkMessage(chunkID string, index int, total int, checksum string, data []byte) *ChunkMessage {
+	return &ChunkMessage{
+		MessageWithType: MessageWithType{
+			Type:     bus.MsgChunk,
+			SendTime: utils.BusTimeNow(),
+		},
+		ChunkID:  chunkID,
+		Index:    index,
+		Total:    total,
+		Checksum: checksum,
+		Data:     data,
+	}
+}
@@ -48,3 +48,21 @@ func TestNewEntityLoadStatusMessage(t *testing.T) {
 	assert.Equal(t, "test_node", m.NodeID, "node")
 	assert.True(t, m.IsSuccess, "success")
 }
+
+// Tests device removed ctor.
+func TestNewDeviceRemovedMessage(t *testing.T) {
+	m := NewDeviceRemovedMessage("test", "test_node")
+	checkTime(t, m.SendTime)
+	assert.Equal(t, "test", m.DeviceID, "device")
+	assert.Equal(t, "test_node", m.WorkerID, "worker")
+}
+
+// Tests device event ctor.
+func TestNewDeviceEventMessage(t *testing.T) {
+	m := NewDeviceEventMessage("test", "test_node", enums.EvtRing, map[string]interface{}{"test": 1})
+	checkTime(t, m.SendTime)
+	assert.Equal(t, "test", m.DeviceID, "device")
+	assert.Equal(t, "test_node", m.WorkerID, "worker")
+	assert.Equal(t, enums.EvtRing, m.Event, "event")
+	assert.Equal(t, 1, m.Data["test"], "data")
+}
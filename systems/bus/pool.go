@@ -0,0 +1,214 @@
+package bus
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"sync/atomic"
+
+	"go-home.io/x/server/plugins/bus"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/providers"
+)
+
+const (
+	// OverflowBlock describes policy which blocks incoming messages until queue has space.
+	OverflowBlock = "block"
+	// OverflowDropNewest describes policy which drops incoming message if queue is full.
+	OverflowDropNewest = "drop-newest"
+	// OverflowDropOldest describes policy which drops the oldest queued message if queue is full.
+	OverflowDropOldest = "drop-oldest"
+
+	// Default number of processing workers.
+	defaultPoolWorkers = 4
+	// Default size of a single processing queue.
+	defaultPoolQueueSize = 100
+)
+
+// IProcessingPoolProvider defines bounded pool for incoming messages processing.
+// Messages with the same key are processed by the same worker, preserving their order.
+type IProcessingPoolProvider interface {
+	Listen(queue chan bus.RawMessage)
+	Submit(msg *bus.RawMessage)
+	Stats() *QueueStats
+}
+
+// QueueStats has processing pool metrics.
+type QueueStats struct {
+	Depth     []int  `json:"depth"`
+	Capacity  int    `json:"capacity"`
+	Processed uint64 `json:"processed"`
+	Dropped   uint64 `json:"dropped"`
+}
+
+// ConstructProcessingPool has data required for a new processing pool.
+type ConstructProcessingPool struct {
+	Logger    common.ILoggerProvider
+	Settings  *providers.BusQueueSettings
+	Processor func(msg *bus.RawMessage)
+}
+
+// Single processing queue.
+type poolQueue struct {
+	messages chan *bus.RawMessage
+	isFull   int32
+}
+
+// Processing pool implementation.
+type processingPool struct {
+	logger    common.ILoggerProvider
+	processor func(msg *bus.RawMessage)
+	overflow  string
+	queueSize int
+	queues    []*poolQueue
+
+	processed uint64
+	dropped   uint64
+}
+
+// Helper type for picking message processing key.
+type messageKey struct {
+	DeviceID string `json:"i"`
+	WorkerID string `json:"w"`
+	NodeID   string `json:"n"`
+}
+
+// NewProcessingPool constructs a new processing pool.
+func NewProcessingPool(ctor *ConstructProcessingPool) IProcessingPoolProvider {
+	p := &processingPool{
+		logger:    ctor.Logger,
+		processor: ctor.Processor,
+		overflow:  OverflowBlock,
+		queueSize: defaultPoolQueueSize,
+	}
+
+	workers := defaultPoolWorkers
+	if nil != ctor.Settings {
+		if ctor.Settings.Workers > 0 {
+			workers = ctor.Settings.Workers
+		}
+
+		if ctor.Settings.Size > 0 {
+			p.queueSize = ctor.Settings.Size
+		}
+
+		if "" != ctor.Settings.Overflow {
+			p.overflow = ctor.Settings.Overflow
+		}
+	}
+
+	p.queues = make([]*poolQueue, workers)
+	for i := range p.queues {
+		p.queues[i] = &poolQueue{
+			messages: make(chan *bus.RawMessage, p.queueSize),
+		}
+
+		go p.process(p.queues[i])
+	}
+
+	return p
+}
+
+// Listen submits all messages from the queue.
+// Should be invoked in a separate goroutine, since blocking policy
+// blocks the caller.
+func (p *processingPool) Listen(queue chan bus.RawMessage) {
+	for msg := range queue {
+		m := msg
+		p.Submit(&m)
+	}
+}
+
+// Submit adds a new message into the processing queue.
+func (p *processingPool) Submit(msg *bus.RawMessage) {
+	q := p.queues[p.getQueueIndex(msg)]
+
+	switch p.overflow {
+	case OverflowDropNewest:
+		select {
+		case q.messages <- msg:
+		default:
+			p.drop(q)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case q.messages <- msg:
+				return
+			default:
+				select {
+				case <-q.messages:
+					p.drop(q)
+				default:
+				}
+			}
+		}
+	default:
+		q.messages <- msg
+	}
+}
+
+// Stats returns current pool metrics.
+func (p *processingPool) Stats() *QueueStats {
+	s := &QueueStats{
+		Depth:     make([]int, len(p.queues)),
+		Capacity:  p.queueSize,
+		Processed: atomic.LoadUint64(&p.processed),
+		Dropped:   atomic.LoadUint64(&p.dropped),
+	}
+
+	for i, v := range p.queues {
+		s.Depth[i] = len(v.messages)
+	}
+
+	return s
+}
+
+// Processes messages from a single queue.
+func (p *processingPool) process(q *poolQueue) {
+	for msg := range q.messages {
+		p.processor(msg)
+		atomic.AddUint64(&p.processed, 1)
+
+		if len(q.messages) < p.queueSize/2 {
+			atomic.StoreInt32(&q.isFull, 0)
+		}
+	}
+}
+
+// Registers dropped message.
+// Warning is logged once per queue overflow.
+func (p *processingPool) drop(q *poolQueue) {
+	dropped := atomic.AddUint64(&p.dropped, 1)
+	if atomic.CompareAndSwapInt32(&q.isFull, 0, 1) {
+		p.logger.Warn("Bus processing queue is full, dropping messages", common.LogSystemToken, logSystem,
+			"policy", p.overflow, "dropped", strconv.FormatUint(dropped, 10))
+	}
+}
+
+// Returns queue index for the message.
+// Device ID is preferred, then worker ID.
+func (p *processingPool) getQueueIndex(msg *bus.RawMessage) int {
+	if 1 == len(p.queues) {
+		return 0
+	}
+
+	k := &messageKey{}
+	err := json.Unmarshal(msg.Body, k)
+	if err != nil {
+		return 0
+	}
+
+	key := k.DeviceID
+	if "" == key {
+		key = k.WorkerID
+	}
+
+	if "" == key {
+		key = k.NodeID
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key)) // nolint: gosec, errcheck
+	return int(h.Sum32() % uint32(len(p.queues)))
+}
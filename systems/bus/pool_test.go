@@ -0,0 +1,96 @@
+package bus
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/bus"
+	"go-home.io/x/server/providers"
+)
+
+// Tests that messages with the same key are processed in order.
+func TestPoolPreservesOrder(t *testing.T) {
+	mutex := sync.Mutex{}
+	processed := make(map[string][]int)
+	wg := sync.WaitGroup{}
+	wg.Add(100)
+
+	p := NewProcessingPool(&ConstructProcessingPool{
+		Logger:   mocks.FakeNewLogger(nil),
+		Settings: &providers.BusQueueSettings{Workers: 4, Size: 10},
+		Processor: func(msg *bus.RawMessage) {
+			var id string
+			var seq int
+			fmt.Sscanf(string(msg.Body), `{"i": "%1s", "s": %d}`, &id, &seq) // nolint: errcheck, gosec
+			mutex.Lock()
+			processed[id] = append(processed[id], seq)
+			mutex.Unlock()
+			wg.Done()
+		},
+	})
+
+	for i := 0; i < 20; i++ {
+		for _, v := range []string{"a", "b", "c", "d", "e"} {
+			p.Submit(&bus.RawMessage{Body: []byte(fmt.Sprintf(`{"i": "%s", "s": %d}`, v, i))})
+		}
+	}
+
+	wg.Wait()
+	for k, v := range processed {
+		require.Equal(t, 20, len(v), k)
+		for i := range v {
+			assert.Equal(t, i, v[i], k)
+		}
+	}
+
+	assert.Equal(t, uint64(100), p.Stats().Processed)
+}
+
+// Tests overflow policies.
+func TestPoolOverflow(t *testing.T) {
+	data := []struct {
+		policy string
+		first  string
+	}{
+		{
+			policy: OverflowDropNewest,
+			first:  `{"i": "1"}`,
+		},
+		{
+			policy: OverflowDropOldest,
+			first:  `{"i": "3"}`,
+		},
+	}
+
+	for _, v := range data {
+		block := make(chan bool)
+		processed := make(chan string, 10)
+		p := NewProcessingPool(&ConstructProcessingPool{
+			Logger:   mocks.FakeNewLogger(nil),
+			Settings: &providers.BusQueueSettings{Workers: 1, Size: 1, Overflow: v.policy},
+			Processor: func(msg *bus.RawMessage) {
+				<-block
+				processed <- string(msg.Body)
+			},
+		})
+
+		p.Submit(&bus.RawMessage{Body: []byte(`{"i": "0"}`)})
+		time.Sleep(100 * time.Millisecond)
+		p.Submit(&bus.RawMessage{Body: []byte(`{"i": "1"}`)})
+		p.Submit(&bus.RawMessage{Body: []byte(`{"i": "2"}`)})
+		p.Submit(&bus.RawMessage{Body: []byte(`{"i": "3"}`)})
+
+		stats := p.Stats()
+		assert.Equal(t, uint64(2), stats.Dropped, v.policy)
+		assert.Equal(t, 1, stats.Depth[0], v.policy)
+
+		close(block)
+		assert.Equal(t, `{"i": "0"}`, <-processed, v.policy)
+		assert.Equal(t, v.first, <-processed, v.policy)
+	}
+}
@@ -2,13 +2,17 @@
 package bus
 
 import (
+	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go-home.io/x/server/plugins/bus"
 	"go-home.io/x/server/plugins/common"
 	"go-home.io/x/server/providers"
 	"go-home.io/x/server/systems"
+	"gopkg.in/yaml.v2"
 )
 
 const (
@@ -27,13 +31,30 @@ type ConstructBus struct {
 }
 
 // Service bus provider.
+// Messages exceeding max message size are transparently split into chunks.
 type provider struct {
-	bus bus.IServiceBus
+	sync.Mutex
+	bus          bus.IServiceBus
+	logger       common.ILoggerProvider
+	chunker      *chunker
+	chunkTimeout time.Duration
+	subs         map[string]chan bool
 }
 
 // NewServiceBusProvider constructs a new service bus provider.
 func NewServiceBusProvider(ctor *ConstructBus) (providers.IBusProvider, error) {
-	p := provider{}
+	settings := &chunkSettings{}
+	err := yaml.Unmarshal(ctor.RawConfig, settings)
+	if err != nil {
+		ctor.Logger.Warn("Failed to read chunking settings, using defaults", common.LogSystemToken, logSystem)
+	}
+
+	p := provider{
+		logger:       ctor.Logger,
+		chunker:      newChunker(ctor.NodeID, settings.MaxMessageSize),
+		chunkTimeout: time.Duration(settings.ChunkTimeout) * time.Second,
+		subs:         make(map[string]chan bool),
+	}
 
 	pluginLoadRequest := &providers.PluginLoadRequest{
 		ExpectedType:   bus.TypeServiceBus,
@@ -61,8 +82,24 @@ func (s *provider) Subscribe(channel bus.ChannelName, queue chan bus.RawMessage)
 	return s.SubscribeStr(channel.String(), queue)
 }
 
+// SubscribeStr subscribes to the channel, re-assembling chunked messages.
 func (s *provider) SubscribeStr(channel string, queue chan bus.RawMessage) error {
-	return s.bus.Subscribe(channel, queue)
+	in := make(chan bus.RawMessage, cap(queue))
+	err := s.bus.Subscribe(channel, in)
+	if err != nil {
+		return err
+	}
+
+	stop := make(chan bool)
+	s.Lock()
+	if prev, ok := s.subs[channel]; ok {
+		close(prev)
+	}
+	s.subs[channel] = stop
+	s.Unlock()
+
+	go s.receive(channel, in, queue, stop)
+	return nil
 }
 
 // SubscribeToWorker is a syntax sugar around worker channels.
@@ -73,6 +110,13 @@ func (s *provider) SubscribeToWorker(workerName string, queue chan bus.RawMessag
 // Unsubscribe removes bus subscription.
 func (s *provider) Unsubscribe(channel string) {
 	s.bus.Unsubscribe(channel)
+
+	s.Lock()
+	defer s.Unlock()
+	if stop, ok := s.subs[channel]; ok {
+		close(stop)
+		delete(s.subs, channel)
+	}
 }
 
 // Publish allows to send a new message.
@@ -80,8 +124,25 @@ func (s *provider) Publish(channel bus.ChannelName, messages ...interface{}) {
 	s.PublishStr(channel.String(), messages...)
 }
 
+// PublishStr sends messages, splitting large ones into chunks.
 func (s *provider) PublishStr(channel string, messages ...interface{}) {
-	s.bus.Publish(channel, messages...)
+	prepared := make([]interface{}, 0, len(messages))
+	for _, v := range messages {
+		body, err := json.Marshal(v)
+		if err != nil {
+			s.logger.Error("Failed to marshal bus message", err, common.LogSystemToken, logSystem,
+				common.LogChannelToken, channel)
+			continue
+		}
+
+		prepared = append(prepared, s.chunker.split(body)...)
+	}
+
+	if 0 == len(prepared) {
+		return
+	}
+
+	s.bus.Publish(channel, prepared...)
 }
 
 // PublishToWorker is a syntax sugar around worker channels.
@@ -93,3 +154,35 @@ func (s *provider) PublishToWorker(workerName string, messages ...interface{}) {
 func (s *provider) Ping() error {
 	return s.bus.Ping()
 }
+
+// Passes incoming messages into the subscriber's queue.
+// Chunks are held until the whole message is received.
+func (s *provider) receive(channel string, in chan bus.RawMessage, out chan bus.RawMessage, stop chan bool) {
+	a := newAssembler(s.logger, channel, s.chunkTimeout)
+	ticker := time.NewTicker(a.timeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			a.cleanup()
+		case msg, ok := <-in:
+			if !ok {
+				return
+			}
+
+			res, ok := a.process(msg)
+			if !ok {
+				continue
+			}
+
+			select {
+			case out <- res:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
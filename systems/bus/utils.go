@@ -7,16 +7,28 @@ import (
 	"go-home.io/x/server/utils"
 )
 
+// Helper type for checking message age.
+type messageHeader struct {
+	MessageWithType
+	PingTime int64 `json:"pt"`
+}
+
 // Parses raw message and checks whether it should be skipped due to the age.
+// Discovery messages and master's replies to them are used for clock offset
+// estimation, so they are not checked: clocks might not be in sync yet.
 func parseRawMessage(r *bus.RawMessage) (*MessageWithType, error) {
-	var b MessageWithType
+	var b messageHeader
 	if err := json.Unmarshal(r.Body, &b); err != nil {
 		return nil, &ErrCorruptedMessage{}
 	}
 
-	if utils.TimeNow()-b.SendTime > bus.MsgTTLSeconds {
+	if bus.MsgPing == b.Type || (0 != b.PingTime && busClock.isRecentPing(b.PingTime)) {
+		return &b.MessageWithType, nil
+	}
+
+	if utils.BusTimeNow()-b.SendTime > bus.MsgTTLSeconds {
 		return nil, &ErrOldMessage{}
 	}
 
-	return &b, nil
+	return &b.MessageWithType, nil
 }
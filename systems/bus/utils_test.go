@@ -23,7 +23,7 @@ func TestWrongJson(t *testing.T) {
 // Tests an old message.
 func TestOldMessage(t *testing.T) {
 	msg := &bus.RawMessage{
-		Body: []byte(fmt.Sprintf(`{ "mt": "ping", "st":  %d }`, utils.TimeNow()-(bus.MsgTTLSeconds+1))),
+		Body: []byte(fmt.Sprintf(`{ "mt": "device_update", "st":  %d }`, utils.TimeNow()-(bus.MsgTTLSeconds+1))),
 	}
 
 	m, err := parseRawMessage(msg)
@@ -41,3 +41,36 @@ func TestCorrectMessage(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, m)
 }
+
+// Tests that discovery messages are not checked for age.
+func TestOldPingMessage(t *testing.T) {
+	msg := &bus.RawMessage{
+		Body: []byte(fmt.Sprintf(`{ "mt": "ping", "st":  %d }`, utils.TimeNow()-(bus.MsgTTLSeconds+100))),
+	}
+
+	m, err := parseRawMessage(msg)
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+}
+
+// Tests that replies to the recent discovery messages are not checked for age.
+func TestOldDiscoveryReply(t *testing.T) {
+	ping := NewDiscoveryMessage("test", false, nil, 1)
+	msg := &bus.RawMessage{
+		Body: []byte(fmt.Sprintf(`{ "mt": "device_assignment", "st":  %d, "pt": %d }`,
+			utils.TimeNow()-(bus.MsgTTLSeconds+100), ping.PingTime)),
+	}
+
+	m, err := parseRawMessage(msg)
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+
+	msg = &bus.RawMessage{
+		Body: []byte(fmt.Sprintf(`{ "mt": "device_assignment", "st":  %d, "pt": %d }`,
+			utils.TimeNow()-(bus.MsgTTLSeconds+100), ping.PingTime-1)),
+	}
+
+	m, err = parseRawMessage(msg)
+	assert.Error(t, err)
+	assert.Nil(t, m)
+}
@@ -3,13 +3,18 @@ package device
 import (
 	"bytes"
 	"encoding/base64"
+	"fmt"
 	"image"
+	"image/color"
+	"image/draw"
 	"image/jpeg"
 	"strings"
 
 	"github.com/corona10/goimagehash"
 	"github.com/disintegration/imaging"
+	"go-home.io/x/server/plugins/device"
 	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/utils"
 	"gopkg.in/yaml.v2"
 )
 
@@ -20,13 +25,38 @@ const (
 	defaultImageQuality = 50
 	// Desired width of the final image.
 	defaultCameraWidth = 800
+	// Maximum allowed width of the final image.
+	// Large pictures are split into chunks by the service bus.
+	maxCameraWidth = 4096
+
+	// Average hash algorithm.
+	hashAverage = "average"
+	// Difference hash algorithm.
+	hashDifference = "difference"
+	// Perception hash algorithm.
+	hashPerception = "perception"
 )
 
 // Device settings.
 type cameraSettings struct {
-	Distance int `yaml:"distance"`
-	Quality  int `yaml:"quality"`
-	Width    int `yaml:"width"`
+	Distance int             `yaml:"distance"`
+	Quality  int             `yaml:"quality"`
+	Width    int             `yaml:"width"`
+	Hash     string          `yaml:"hash"`
+	Interval int             `yaml:"interval"`
+	Regions  []*cameraRegion `yaml:"regions"`
+	Exclude  []*cameraRegion `yaml:"exclude"`
+}
+
+// Rectangular part of the frame.
+// Position and size are set in percents of the frame size.
+type cameraRegion struct {
+	Name     string  `yaml:"name"`
+	X        float64 `yaml:"x"`
+	Y        float64 `yaml:"y"`
+	Width    float64 `yaml:"width"`
+	Height   float64 `yaml:"height"`
+	Distance int     `yaml:"distance"`
 }
 
 // Post-processor for a camera device.
@@ -34,11 +64,26 @@ type cameraProcessor struct {
 	distance int
 	quality  int
 	width    int
-	prevHash *goimagehash.ImageHash
+	interval int64
+	hash     func(image.Image) (*goimagehash.ImageHash, error)
+	regions  []*cameraRegion
+	exclude  []*cameraRegion
+	events   chan *device.EventData
+
+	// Whether regions of interest were configured, otherwise whole frame is used.
+	hasRegions bool
+
+	prevHashes []*goimagehash.ImageHash
+	lastSent   int64
+
+	// Whether a change was suppressed by the interval and has to be published later.
+	pending         bool
+	pendingDistance int
 }
 
 // Constructs a new camera processor.
-func newCameraProcessor(rawConfig string) IProcessor {
+// Motion events are sent to the events channel, if it's provided.
+func newCameraProcessor(rawConfig string, events chan *device.EventData) IProcessor {
 	s := &cameraSettings{}
 	err := yaml.Unmarshal([]byte(rawConfig), s)
 	if err != nil {
@@ -46,6 +91,10 @@ func newCameraProcessor(rawConfig string) IProcessor {
 			distance: defaultCameraDistance,
 			quality:  defaultImageQuality,
 			width:    defaultCameraWidth,
+			hash:     goimagehash.AverageHash,
+			regions:  []*cameraRegion{getFullFrameRegion(defaultCameraDistance)},
+			exclude:  make([]*cameraRegion, 0),
+			events:   events,
 		}
 	}
 
@@ -57,11 +106,73 @@ func newCameraProcessor(rawConfig string) IProcessor {
 		s.Quality = defaultImageQuality
 	}
 
-	if s.Width < 0 || s.Width > 2000 {
+	if s.Width < 0 || s.Width > maxCameraWidth {
 		s.Width = defaultCameraWidth
 	}
 
-	return &cameraProcessor{distance: s.Distance, quality: s.Quality, width: s.Width}
+	if s.Interval < 0 {
+		s.Interval = 0
+	}
+
+	p := &cameraProcessor{
+		distance: s.Distance,
+		quality:  s.Quality,
+		width:    s.Width,
+		interval: int64(s.Interval) * 1000,
+		hash:     getHashFunc(s.Hash),
+		regions:  getValidRegions(s.Regions, s.Distance),
+		exclude:  getValidRegions(s.Exclude, s.Distance),
+		events:   events,
+	}
+
+	p.hasRegions = 0 != len(p.regions)
+	if !p.hasRegions {
+		p.regions = []*cameraRegion{getFullFrameRegion(s.Distance)}
+	}
+
+	return p
+}
+
+// Returns region, which covers the whole frame.
+func getFullFrameRegion(distance int) *cameraRegion {
+	return &cameraRegion{Width: 100, Height: 100, Distance: distance}
+}
+
+// Returns hash function by its name.
+// Average hash is used by default.
+func getHashFunc(name string) func(image.Image) (*goimagehash.ImageHash, error) {
+	switch name {
+	case hashDifference:
+		return goimagehash.DifferenceHash
+	case hashPerception:
+		return goimagehash.PerceptionHash
+	}
+
+	return goimagehash.AverageHash
+}
+
+// Returns regions which fit into the frame.
+// Regions without name or threshold get the default ones.
+func getValidRegions(regions []*cameraRegion, distance int) []*cameraRegion {
+	valid := make([]*cameraRegion, 0)
+	for ii, v := range regions {
+		if nil == v || v.X < 0 || v.Y < 0 || v.Width <= 0 || v.Height <= 0 ||
+			v.X+v.Width > 100 || v.Y+v.Height > 100 {
+			continue
+		}
+
+		if "" == v.Name {
+			v.Name = fmt.Sprintf("region-%d", ii+1)
+		}
+
+		if v.Distance < 1 {
+			v.Distance = distance
+		}
+
+		valid = append(valid, v)
+	}
+
+	return valid
 }
 
 // IsExtraProperty checks whether property is an extra property
@@ -86,28 +197,135 @@ func (p *cameraProcessor) IsPropertyGood(prop enums.Property, val interface{}) (
 		return false, nil
 	}
 
-	hash, err := goimagehash.AverageHash(img)
+	hashes, err := p.getHashes(img)
 	if err != nil {
 		return false, nil
 	}
 
-	if nil == p.prevHash {
-		p.prevHash = hash
+	if nil == p.prevHashes {
+		p.prevHashes = hashes
+		p.lastSent = utils.TimeNowMs()
 		return p.resizeImage(img, defaultCameraDistance)
 	}
 
-	distance, err := p.prevHash.Distance(hash)
-	p.prevHash = hash
-
+	distance, changed, err := p.compareHashes(hashes)
 	if err != nil {
 		return false, nil
 	}
 
-	if distance >= p.distance {
-		return p.resizeImage(img, distance)
+	p.prevHashes = hashes
+	if 0 != len(changed) {
+		p.sendMotion(distance, changed)
+		p.pending = true
+		if distance > p.pendingDistance {
+			p.pendingDistance = distance
+		}
+	}
+
+	if !p.pending || utils.TimeNowMs()-p.lastSent < p.interval {
+		return false, nil
+	}
+
+	distance = p.pendingDistance
+	p.pending = false
+	p.pendingDistance = 0
+	p.lastSent = utils.TimeNowMs()
+	return p.resizeImage(img, distance)
+}
+
+// Calculates hashes of the frame with masked exclude regions.
+// Every region of interest is hashed separately, whole frame is used if there are no regions.
+func (p *cameraProcessor) getHashes(img image.Image) ([]*goimagehash.ImageHash, error) {
+	if 0 != len(p.exclude) {
+		masked := imaging.Clone(img)
+		for _, v := range p.exclude {
+			draw.Draw(masked, v.getRect(masked.Bounds()), image.NewUniform(color.Black), image.Point{}, draw.Src)
+		}
+
+		img = masked
+	}
+
+	hashes := make([]*goimagehash.ImageHash, len(p.regions))
+	for ii, v := range p.regions {
+		region := img
+		if p.hasRegions {
+			region = imaging.Crop(img, v.getRect(img.Bounds()))
+		}
+
+		hash, err := p.hash(region)
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[ii] = hash
+	}
+
+	return hashes, nil
+}
+
+// Compares hashes with the previous frame.
+// Returns maximum distance and names of regions, which exceeded their thresholds.
+func (p *cameraProcessor) compareHashes(hashes []*goimagehash.ImageHash) (int, []string, error) {
+	maxDistance := 0
+	changed := make([]string, 0)
+	for ii, v := range hashes {
+		distance, err := p.prevHashes[ii].Distance(v)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if distance >= p.regions[ii].Distance {
+			changed = append(changed, p.regions[ii].Name)
+			if distance > maxDistance {
+				maxDistance = distance
+			}
+		}
+	}
+
+	return maxDistance, changed, nil
+}
+
+// Sends motion event.
+// Processor is invoked from the same goroutine which reads events, so event is dropped if channel is full.
+func (p *cameraProcessor) sendMotion(distance int, changed []string) {
+	if nil == p.events {
+		return
+	}
+
+	data := map[string]interface{}{enums.PropDistance.String(): distance}
+	if p.hasRegions {
+		data["regions"] = changed
+	}
+
+	select {
+	case p.events <- &device.EventData{
+		Event: enums.EvtMotion,
+		Data:  data,
+	}:
+	default:
+	}
+}
+
+// Returns region rectangle within the frame bounds.
+// Rectangle is at least one pixel in size.
+func (r *cameraRegion) getRect(bounds image.Rectangle) image.Rectangle {
+	w := float64(bounds.Dx())
+	h := float64(bounds.Dy())
+	rect := image.Rect(
+		bounds.Min.X+int(r.X*w/100),
+		bounds.Min.Y+int(r.Y*h/100),
+		bounds.Min.X+int((r.X+r.Width)*w/100),
+		bounds.Min.Y+int((r.Y+r.Height)*h/100))
+
+	if rect.Dx() < 1 {
+		rect.Max.X = rect.Min.X + 1
+	}
+
+	if rect.Dy() < 1 {
+		rect.Max.Y = rect.Min.Y + 1
 	}
 
-	return false, nil
+	return rect.Intersect(bounds)
 }
 
 // Performs image resizing.
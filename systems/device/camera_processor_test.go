@@ -11,13 +11,14 @@ import (
 	"github.com/corona10/goimagehash"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/plugins/device"
 	"go-home.io/x/server/plugins/device/enums"
 )
 
 func getCamera() IProcessor {
 	return newDeviceProcessor(enums.DevCamera, `
 width: 760
-quality: 50`)
+quality: 50`, nil)
 }
 
 //noinspection GoUnhandledErrorResult
@@ -93,3 +94,217 @@ func TestExtraProperties(t *testing.T) {
 		assert.True(t, c.IsExtraProperty(v), v.String())
 	}
 }
+
+// Returns image with the white half, left or right one.
+//noinspection GoUnhandledErrorResult
+func getHalfImage(left bool) string {
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			if x < 8 == left {
+				img.Set(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+				continue
+			}
+
+			img.Set(x, y, color.RGBA{A: 255})
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	jpeg.Encode(buf, img, &jpeg.Options{Quality: 100})
+	return string(buf.Bytes())
+}
+
+// Tests motion events.
+func TestMotionEvent(t *testing.T) {
+	events := make(chan *device.EventData, 1)
+	c := newCameraProcessor("", events)
+
+	ok, _ := c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	require.True(t, ok, "first image")
+	assert.Equal(t, 0, len(events), "first image event")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	require.False(t, ok, "same image")
+	assert.Equal(t, 0, len(events), "same image event")
+
+	ok, out := c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	require.True(t, ok, "motion")
+	require.Equal(t, 1, len(events), "motion event")
+	e := <-events
+	assert.Equal(t, enums.EvtMotion, e.Event, "event")
+	assert.Equal(t, out[enums.PropDistance], e.Data["distance"], "distance")
+
+	events <- &device.EventData{}
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	assert.True(t, ok, "full channel")
+}
+
+// Returns image split into 4 quadrants, every quadrant has white left or right half.
+//noinspection GoUnhandledErrorResult
+func getQuadImage(flipped [4]bool) string {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for x := 0; x < 32; x++ {
+		for y := 0; y < 32; y++ {
+			quad := x/16 + 2*(y/16)
+			if x%16 < 8 != flipped[quad] {
+				img.Set(x, y, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+				continue
+			}
+
+			img.Set(x, y, color.RGBA{A: 255})
+		}
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0))
+	jpeg.Encode(buf, img, &jpeg.Options{Quality: 100})
+	return string(buf.Bytes())
+}
+
+// Tests camera processor settings.
+func TestCameraProcessorSettings(t *testing.T) {
+	c := newCameraProcessor(`
+hash: perception
+interval: 10
+regions:
+  - x: 0
+    y: 0
+    width: 50
+    height: 50
+  - name: door
+    x: 50
+    y: 50
+    width: 50
+    height: 50
+    distance: 5
+  - x: 60
+    y: 0
+    width: 50
+    height: 10
+exclude:
+  - x: -1
+    y: 0
+    width: 10
+    height: 10
+`, nil).(*cameraProcessor)
+
+	img, _ := getImage()
+	jp, err := jpeg.Decode(bytes.NewReader([]byte(img)))
+	require.NoError(t, err, "image")
+	hash, err := c.hash(jp)
+	require.NoError(t, err, "hash")
+	assert.Equal(t, goimagehash.PHash, hash.GetKind(), "hash kind")
+
+	assert.Equal(t, int64(10000), c.interval, "interval")
+	assert.True(t, c.hasRegions, "has regions")
+	require.Equal(t, 2, len(c.regions), "regions")
+	assert.Equal(t, "region-1", c.regions[0].Name, "default name")
+	assert.Equal(t, defaultCameraDistance, c.regions[0].Distance, "default distance")
+	assert.Equal(t, "door", c.regions[1].Name, "name")
+	assert.Equal(t, 5, c.regions[1].Distance, "distance")
+	assert.Equal(t, 0, len(c.exclude), "exclude")
+
+	c = getCamera().(*cameraProcessor)
+	assert.False(t, c.hasRegions, "full frame")
+	require.Equal(t, 1, len(c.regions), "full frame region")
+	assert.Equal(t, image.Rect(0, 0, 32, 32), c.regions[0].getRect(image.Rect(0, 0, 32, 32)), "full frame rect")
+}
+
+// Tests regions of interest and exclude regions.
+func TestCameraRegions(t *testing.T) {
+	events := make(chan *device.EventData, 10)
+	c := newCameraProcessor(`
+regions:
+  - name: top
+    x: 0
+    y: 0
+    width: 100
+    height: 50
+  - name: bottom
+    x: 0
+    y: 50
+    width: 100
+    height: 50
+    distance: 65
+exclude:
+  - x: 0
+    y: 0
+    width: 50
+    height: 50
+`, events)
+
+	ok, _ := c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{}))
+	require.True(t, ok, "first image")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{true, false, false, false}))
+	assert.False(t, ok, "excluded region")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{true, false, true, true}))
+	assert.False(t, ok, "region threshold")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{true, true, true, true}))
+	assert.True(t, ok, "region of interest")
+
+	require.Equal(t, 1, len(events), "events")
+	e := <-events
+	assert.Equal(t, []string{"top"}, e.Data["regions"], "event regions")
+}
+
+// Tests exclude regions without regions of interest.
+func TestCameraExclude(t *testing.T) {
+	c := newCameraProcessor(`
+exclude:
+  - x: 0
+    y: 0
+    width: 50
+    height: 100
+`, nil)
+
+	ok, _ := c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{}))
+	require.True(t, ok, "first image")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{true, false, true, false}))
+	assert.False(t, ok, "excluded region")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getQuadImage([4]bool{true, true, true, true}))
+	assert.True(t, ok, "included region")
+}
+
+// Tests minimum interval between published frames.
+func TestCameraInterval(t *testing.T) {
+	events := make(chan *device.EventData, 10)
+	c := newCameraProcessor("interval: 10", events)
+
+	ok, _ := c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	require.True(t, ok, "first image")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	assert.False(t, ok, "interval")
+	assert.Equal(t, 1, len(events), "event")
+
+	c.(*cameraProcessor).lastSent -= 10000
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	assert.True(t, ok, "after interval")
+}
+
+// Tests that change suppressed by the interval is published later.
+func TestCameraIntervalPending(t *testing.T) {
+	c := newCameraProcessor("interval: 10", nil)
+
+	ok, _ := c.IsPropertyGood(enums.PropPicture, getHalfImage(true))
+	require.True(t, ok, "first image")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	require.False(t, ok, "interval")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	assert.False(t, ok, "still within interval")
+
+	c.(*cameraProcessor).lastSent -= 10000
+	ok, data := c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	require.True(t, ok, "suppressed change")
+	assert.NotEqual(t, 0, data[enums.PropDistance], "distance")
+
+	ok, _ = c.IsPropertyGood(enums.PropPicture, getHalfImage(false))
+	assert.False(t, ok, "already published")
+}
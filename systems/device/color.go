@@ -0,0 +1,85 @@
+package device
+
+import (
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+)
+
+// Emulates color commands, which are not supported by the light plugin.
+// Color temperature is sent as RGB color and vice versa.
+func (w *deviceWrapper) emulateColorCommands() {
+	w.emulated = make(map[enums.Command]enums.Command)
+	if enums.DevLight != w.Ctor.DeviceType {
+		return
+	}
+
+	_, color := w.commands[enums.CmdSetColor]
+	_, temperature := w.commands[enums.CmdSetColorTemperature]
+
+	switch {
+	case color && !temperature:
+		w.emulated[enums.CmdSetColorTemperature] = enums.CmdSetColor
+		w.CommandsStr = append(w.CommandsStr, enums.CmdSetColorTemperature.String())
+	case temperature && !color:
+		w.emulated[enums.CmdSetColor] = enums.CmdSetColorTemperature
+		w.CommandsStr = append(w.CommandsStr, enums.CmdSetColor.String())
+	}
+}
+
+// Converts color command params to the form supported by the plugin.
+// Color accepts RGB or HSV form, color temperature accepts kelvins or mireds.
+func (w *deviceWrapper) adaptColorCommand(cmdName enums.Command,
+	param map[string]interface{}) (enums.Command, map[string]interface{}, error) {
+	switch cmdName {
+	case enums.CmdSetColor:
+		c, err := helpers.ParseColor(param)
+		if err != nil {
+			return cmdName, param, err
+		}
+
+		if target, ok := w.emulated[cmdName]; ok {
+			return target, map[string]interface{}{"value": helpers.RGBToColorTemperature(c)}, nil
+		}
+
+		return cmdName, map[string]interface{}{"r": c.R, "g": c.G, "b": c.B}, nil
+	case enums.CmdSetColorTemperature:
+		kelvin, err := colorTemperatureParam(param)
+		if err != nil {
+			return cmdName, param, err
+		}
+
+		if target, ok := w.emulated[cmdName]; ok {
+			c := helpers.ColorTemperatureToRGB(kelvin)
+			return target, map[string]interface{}{"r": c.R, "g": c.G, "b": c.B}, nil
+		}
+
+		return cmdName, map[string]interface{}{"value": kelvin}, nil
+	}
+
+	return cmdName, param, nil
+}
+
+// Returns color temperature in kelvins.
+func colorTemperatureParam(param map[string]interface{}) (int, error) {
+	key := "value"
+	if _, ok := param["mireds"]; ok {
+		key = "mireds"
+	}
+
+	val, err := helpers.CommandPropertyFixYaml(param[key], enums.CmdSetColorTemperature)
+	if err != nil {
+		return 0, err
+	}
+
+	i, ok := val.(common.Int)
+	if !ok {
+		return 0, &ErrInvalidColorTemperature{}
+	}
+
+	if "mireds" == key {
+		return helpers.MiredsToKelvin(i.Value), nil
+	}
+
+	return i.Value, nil
+}
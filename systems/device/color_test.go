@@ -0,0 +1,160 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Fake RGB light plugin.
+type fakeLight struct {
+	color common.Color
+}
+
+func (*fakeLight) Init(*device.InitDataDevice) error {
+	return nil
+}
+
+func (*fakeLight) Unload() {
+}
+
+func (*fakeLight) GetName() string {
+	return "fake light"
+}
+
+func (*fakeLight) GetSpec() *device.Spec {
+	return &device.Spec{
+		SupportedCommands:   []enums.Command{enums.CmdOn, enums.CmdSetColor},
+		SupportedProperties: []enums.Property{enums.PropOn, enums.PropColor},
+	}
+}
+
+func (f *fakeLight) Load() (*device.LightState, error) {
+	return &device.LightState{On: true, Color: f.color}, nil
+}
+
+func (f *fakeLight) Update() (*device.LightState, error) {
+	return f.Load()
+}
+
+func (*fakeLight) On() error {
+	return nil
+}
+
+func (*fakeLight) Off() error {
+	return nil
+}
+
+func (*fakeLight) Toggle() error {
+	return nil
+}
+
+func (*fakeLight) SetBrightness(device.GradualBrightness) error {
+	return nil
+}
+
+func (*fakeLight) SetScene(common.String) error {
+	return nil
+}
+
+func (f *fakeLight) SetColor(c common.Color) error {
+	f.color = c
+	return nil
+}
+
+func (*fakeLight) SetTransitionTime(common.Int) error {
+	return nil
+}
+
+// Fake white spectrum light plugin.
+type fakeWhiteLight struct {
+	fakeLight
+	temperature int
+}
+
+func (*fakeWhiteLight) GetSpec() *device.Spec {
+	return &device.Spec{
+		SupportedCommands:   []enums.Command{enums.CmdOn, enums.CmdSetColorTemperature},
+		SupportedProperties: []enums.Property{enums.PropOn, enums.PropColorTemperature},
+	}
+}
+
+func (f *fakeWhiteLight) Load() (*device.LightState, error) {
+	return &device.LightState{On: true, ColorTemperature: f.temperature}, nil
+}
+
+func (f *fakeWhiteLight) Update() (*device.LightState, error) {
+	return f.Load()
+}
+
+func (f *fakeWhiteLight) SetColorTemperature(t common.Int) error {
+	f.temperature = t.Value
+	return nil
+}
+
+// Loads fake light wrapper.
+func getFakeLightWrapper(t *testing.T, s device.ILight) *deviceWrapper {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddLoader(s)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevLight,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	require.Equal(t, 1, len(wrappers), "wrappers")
+	return wrappers[0].(*deviceWrapper)
+}
+
+// Tests color commands on RGB light.
+func TestRGBLight(t *testing.T) {
+	s := &fakeLight{}
+	w := getFakeLightWrapper(t, s)
+	defer w.Unload()
+
+	assert.Equal(t, []string{"on", "set-color", "set-color-temperature"}, w.CommandsStr, "commands")
+
+	w.InvokeCommand(enums.CmdSetColor, map[string]interface{}{"r": 10, "g": 20, "b": 30})
+	assert.Equal(t, common.Color{R: 10, G: 20, B: 30}, s.color, "rgb")
+
+	w.InvokeCommand(enums.CmdSetColor, map[string]interface{}{"h": 240.0, "s": 100.0, "v": 100.0})
+	assert.Equal(t, common.Color{R: 0, G: 0, B: 255}, s.color, "hsv")
+
+	w.InvokeCommand(enums.CmdSetColorTemperature, map[string]interface{}{"value": 6600})
+	assert.Equal(t, common.Color{R: 255, G: 255, B: 255}, s.color, "kelvin")
+
+	w.InvokeCommand(enums.CmdSetColorTemperature, map[string]interface{}{"mireds": 500})
+	assert.True(t, s.color.R > s.color.G && s.color.G > s.color.B, "mireds")
+
+	s.color = common.Color{}
+	w.InvokeCommand(enums.CmdSetColorTemperature, map[string]interface{}{"value": "warm"})
+	assert.Equal(t, common.Color{}, s.color, "wrong temperature")
+}
+
+// Tests color commands on white spectrum light.
+func TestWhiteLight(t *testing.T) {
+	s := &fakeWhiteLight{temperature: 2700}
+	w := getFakeLightWrapper(t, s)
+	defer w.Unload()
+
+	assert.Equal(t, []string{"on", "set-color-temperature", "set-color"}, w.CommandsStr, "commands")
+	assert.Equal(t, 2700, w.State[enums.PropColorTemperature.String()], "state")
+
+	w.InvokeCommand(enums.CmdSetColorTemperature, map[string]interface{}{"value": 4000.0})
+	assert.Equal(t, 4000, s.temperature, "kelvin")
+
+	w.InvokeCommand(enums.CmdSetColorTemperature, map[string]interface{}{"mireds": 250})
+	assert.Equal(t, 4000, s.temperature, "mireds")
+
+	w.InvokeCommand(enums.CmdSetColor, map[string]interface{}{"r": 255, "g": 255, "b": 255})
+	assert.InDelta(t, 6500, s.temperature, 500, "rgb")
+}
@@ -0,0 +1,138 @@
+package device
+
+import (
+	"sync"
+	"time"
+
+	"go-home.io/x/server/plugins/device/enums"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Default time after which queued command is dropped.
+	defaultCommandTTL = 10 * time.Second
+)
+
+// Commands which depend on the current device state, so every invocation matters.
+var nonIdempotentCommands = []enums.Command{
+	enums.CmdToggle, enums.CmdMute, enums.CmdNext, enums.CmdPrevious, enums.CmdFindMe,
+}
+
+// Commands queue settings, loaded from device config.
+// Interval is in milliseconds, TTL is in seconds.
+type commandQueueSettings struct {
+	Interval int `yaml:"commandInterval"`
+	TTL      int `yaml:"commandTTL"`
+}
+
+// Single queued command.
+type queuedCommand struct {
+	cmd    enums.Command
+	params map[string]interface{}
+	queued time.Time
+}
+
+// Per-device commands queue.
+// Superseded idempotent commands of the same type are coalesced, so only the latest one is invoked.
+type commandQueue struct {
+	sync.Mutex
+
+	interval    time.Duration
+	ttl         time.Duration
+	commands    []*queuedCommand
+	processing  bool
+	lastCommand time.Time
+}
+
+// Constructs a new commands queue.
+func newCommandQueue(rawConfig string) *commandQueue {
+	q := &commandQueue{
+		ttl:      defaultCommandTTL,
+		commands: make([]*queuedCommand, 0),
+	}
+
+	s := &commandQueueSettings{}
+	err := yaml.Unmarshal([]byte(rawConfig), s)
+	if err != nil {
+		return q
+	}
+
+	if s.Interval > 0 {
+		q.interval = time.Duration(s.Interval) * time.Millisecond
+	}
+
+	if s.TTL > 0 {
+		q.ttl = time.Duration(s.TTL) * time.Second
+	}
+
+	return q
+}
+
+// Adds command to the queue and removes superseded command of the same type.
+// Non-idempotent commands, e.g. toggle, are never coalesced.
+// Returns true if caller should process the queue.
+func (q *commandQueue) push(cmd enums.Command, params map[string]interface{}) bool {
+	q.Lock()
+	defer q.Unlock()
+
+	coalesce := !enums.SliceContainsCommand(nonIdempotentCommands, cmd)
+	commands := make([]*queuedCommand, 0, len(q.commands)+1)
+	for _, v := range q.commands {
+		if !coalesce || v.cmd != cmd {
+			commands = append(commands, v)
+		}
+	}
+
+	q.commands = append(commands, &queuedCommand{
+		cmd:    cmd,
+		params: params,
+		queued: time.Now(),
+	})
+
+	if q.processing {
+		return false
+	}
+
+	q.processing = true
+	return true
+}
+
+// Returns next command, waiting for the minimal interval since the previous one.
+// Returns nil and stops processing if queue is empty.
+func (q *commandQueue) next() *queuedCommand {
+	q.Lock()
+	defer q.Unlock()
+
+	for {
+		if 0 == len(q.commands) {
+			q.processing = false
+			return nil
+		}
+
+		wait := time.Until(q.lastCommand.Add(q.interval))
+		if wait <= 0 {
+			break
+		}
+
+		q.Unlock()
+		time.Sleep(wait)
+		q.Lock()
+	}
+
+	c := q.commands[0]
+	q.commands = q.commands[1:]
+	return c
+}
+
+// Checks whether command was queued for too long.
+func (q *commandQueue) expired(c *queuedCommand) bool {
+	return time.Since(c.queued) > q.ttl
+}
+
+// Marks command as invoked.
+func (q *commandQueue) done() {
+	q.Lock()
+	defer q.Unlock()
+
+	q.lastCommand = time.Now()
+}
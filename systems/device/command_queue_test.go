@@ -0,0 +1,116 @@
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Tests commands queue settings.
+func TestCommandQueueSettings(t *testing.T) {
+	q := newCommandQueue("commandInterval: 200\ncommandTTL: 2")
+	assert.Equal(t, 200*time.Millisecond, q.interval, "interval")
+	assert.Equal(t, 2*time.Second, q.ttl, "ttl")
+
+	q = newCommandQueue("commandInterval: [")
+	assert.Equal(t, time.Duration(0), q.interval, "default interval")
+	assert.Equal(t, defaultCommandTTL, q.ttl, "default ttl")
+}
+
+// Tests that superseded commands are coalesced.
+func TestCommandQueueCoalescing(t *testing.T) {
+	q := newCommandQueue("")
+	assert.True(t, q.push(enums.CmdOn, nil), "first push")
+	assert.False(t, q.push(enums.CmdSetBrightness, map[string]interface{}{"value": 10}), "second push")
+	assert.False(t, q.push(enums.CmdOff, nil), "third push")
+	assert.False(t, q.push(enums.CmdSetBrightness, map[string]interface{}{"value": 50}), "fourth push")
+
+	expected := []enums.Command{enums.CmdOn, enums.CmdOff, enums.CmdSetBrightness}
+	for _, v := range expected {
+		c := q.next()
+		require.NotNil(t, c, v.String())
+		assert.Equal(t, v, c.cmd, v.String())
+	}
+
+	assert.Nil(t, q.next(), "empty queue")
+	assert.True(t, q.push(enums.CmdOn, nil), "push after processing")
+}
+
+// Tests that non-idempotent commands are not coalesced.
+func TestCommandQueueToggle(t *testing.T) {
+	q := newCommandQueue("")
+	assert.True(t, q.push(enums.CmdToggle, nil), "first push")
+	assert.False(t, q.push(enums.CmdToggle, nil), "second push")
+	assert.False(t, q.push(enums.CmdMute, nil), "third push")
+	assert.False(t, q.push(enums.CmdMute, nil), "fourth push")
+
+	expected := []enums.Command{enums.CmdToggle, enums.CmdToggle, enums.CmdMute, enums.CmdMute}
+	for _, v := range expected {
+		c := q.next()
+		require.NotNil(t, c, v.String())
+		assert.Equal(t, v, c.cmd, v.String())
+	}
+
+	assert.Nil(t, q.next(), "empty queue")
+}
+
+// Tests minimal interval between commands and TTL.
+func TestCommandQueueIntervalAndTTL(t *testing.T) {
+	q := newCommandQueue("commandInterval: 100")
+	q.push(enums.CmdOn, nil)
+	q.next()
+	q.done()
+
+	q.push(enums.CmdOff, nil)
+	start := time.Now()
+	c := q.next()
+	require.NotNil(t, c, "command")
+	assert.True(t, time.Since(start) >= 90*time.Millisecond, "interval")
+	assert.False(t, q.expired(c), "not expired")
+
+	q.ttl = time.Millisecond
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, q.expired(c), "expired")
+}
+
+// Tests that queued idempotent commands are coalesced by the wrapper.
+func TestWrapperCommandsCoalescing(t *testing.T) {
+	s := &fakeMediaPlayer{volume: 10}
+	w := getFakeMediaPlayerWrapper(t, s)
+	defer w.Unload()
+
+	w.queue.processing = true
+	w.InvokeCommand(enums.CmdMute, nil)
+	w.InvokeCommand(enums.CmdSetVolume, map[string]interface{}{"value": 20})
+	w.InvokeCommand(enums.CmdMute, nil)
+	w.InvokeCommand(enums.CmdSetVolume, map[string]interface{}{"value": 40})
+	assert.Equal(t, uint8(10), s.volume, "queued")
+
+	w.queue.processing = false
+	w.InvokeCommand(enums.CmdPlay, nil)
+	assert.Equal(t, uint8(40), s.volume, "volume")
+	assert.False(t, s.muted, "mute invoked twice")
+	assert.Equal(t, 0, len(w.queue.commands), "queue")
+}
+
+// Tests that queued commands are invoked in order without blocking the caller.
+func TestWrapperQueueCommand(t *testing.T) {
+	s := &fakeMediaPlayer{volume: 10}
+	w := getFakeMediaPlayerWrapper(t, s)
+	defer w.Unload()
+
+	w.queue.interval = 100 * time.Millisecond
+	start := time.Now()
+	w.QueueCommand(enums.CmdMute, nil)
+	w.QueueCommand(enums.CmdSetVolume, map[string]interface{}{"value": 20})
+	w.QueueCommand(enums.CmdMute, nil)
+	assert.True(t, time.Since(start) < 50*time.Millisecond, "caller was blocked")
+
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, uint8(20), s.volume, "volume")
+	assert.False(t, s.muted, "mute invoked twice")
+	assert.Equal(t, 0, len(w.queue.commands), "queue")
+}
@@ -0,0 +1,45 @@
+package device
+
+import (
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+)
+
+// Commands, which accept only enum values.
+// Checker returns whether value is a known one.
+var enumCommands = map[enums.Command]func(string) bool{
+	enums.CmdSetMode: func(s string) bool {
+		v, err := enums.HvacModeString(s)
+		return nil == err && enums.HvacModeUnknown != v
+	},
+	enums.CmdSetFanMode: func(s string) bool {
+		v, err := enums.FanModeString(s)
+		return nil == err && enums.FanModeUnknown != v
+	},
+	enums.CmdSetDirection: func(s string) bool {
+		v, err := enums.FanDirectionString(s)
+		return nil == err && enums.DirectionUnknown != v
+	},
+}
+
+// Validates string command params, which are expected to be one of the enum values.
+// "unknown" value is rejected as well, so plugins receive only known values.
+func validateEnumCommand(cmdName enums.Command, param map[string]interface{}) error {
+	isKnown, ok := enumCommands[cmdName]
+	if !ok {
+		return nil
+	}
+
+	val, err := helpers.CommandPropertyFixYaml(param["value"], cmdName)
+	if err != nil {
+		return err
+	}
+
+	s, ok := val.(common.String)
+	if !ok || !isKnown(s.Value) {
+		return &ErrInvalidCommandValue{Value: s.Value}
+	}
+
+	return nil
+}
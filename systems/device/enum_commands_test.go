@@ -0,0 +1,33 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Tests enum commands validation.
+func TestValidateEnumCommand(t *testing.T) {
+	data := []struct {
+		cmd   enums.Command
+		value interface{}
+		valid bool
+	}{
+		{enums.CmdSetMode, "fan_only", true},
+		{enums.CmdSetMode, "unknown", false},
+		{enums.CmdSetMode, "freeze", false},
+		{enums.CmdSetMode, nil, false},
+		{enums.CmdSetFanMode, "auto", true},
+		{enums.CmdSetFanMode, "turbo", false},
+		{enums.CmdSetDirection, "reverse", true},
+		{enums.CmdSetDirection, "backward", false},
+		{enums.CmdSetDirection, "unknown", false},
+		{enums.CmdSelectSource, "anything", true},
+	}
+
+	for _, v := range data {
+		err := validateEnumCommand(v.cmd, map[string]interface{}{"value": v.value})
+		assert.Equal(t, v.valid, nil == err, "%s %v", v.cmd.String(), v.value)
+	}
+}
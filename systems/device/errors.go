@@ -17,3 +17,51 @@ type ErrNoDataFromPlugin struct {
 func (*ErrNoDataFromPlugin) Error() string {
 	return "plugin didn't return any data"
 }
+
+// ErrPluginPanic defines panic in plugin call.
+type ErrPluginPanic struct {
+	Message string
+}
+
+// Error formats output.
+func (e *ErrPluginPanic) Error() string {
+	return "plugin panicked: " + e.Message
+}
+
+// ErrPluginTimeout defines plugin call which didn't finish in time.
+type ErrPluginTimeout struct {
+}
+
+// Error formats output.
+func (*ErrPluginTimeout) Error() string {
+	return "plugin call timed out"
+}
+
+// ErrInvalidColorTemperature defines missing or wrong color temperature.
+type ErrInvalidColorTemperature struct {
+}
+
+// Error formats output.
+func (*ErrInvalidColorTemperature) Error() string {
+	return "invalid color temperature"
+}
+
+// ErrWrongProcessorStage defines wrong processor stage settings.
+type ErrWrongProcessorStage struct {
+	Reason string
+}
+
+// Error formats output.
+func (e *ErrWrongProcessorStage) Error() string {
+	return "wrong processor stage: " + e.Reason
+}
+
+// ErrInvalidCommandValue defines value, which is not supported by the command.
+type ErrInvalidCommandValue struct {
+	Value string
+}
+
+// Error formats output.
+func (e *ErrInvalidCommandValue) Error() string {
+	return "invalid command value: " + e.Value
+}
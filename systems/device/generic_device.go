@@ -52,6 +52,7 @@ func LoadDevice(ctor *ConstructDevice) ([]IDeviceWrapperProvider, error) {
 		UOM:                   ctor.UOM,
 		DeviceDiscoveredChan:  make(chan *device.DiscoveredDevices, 3),
 		DeviceStateUpdateChan: make(chan *device.StateUpdateData, 10),
+		DeviceEventChan:       make(chan *device.EventData, 10),
 	}
 
 	expectedType, err := getExpectedType(ctor.DeviceType)
@@ -97,7 +98,7 @@ func LoadDevice(ctor *ConstructDevice) ([]IDeviceWrapperProvider, error) {
 		DiscoveryChan:     ctor.DiscoveryChan,
 		StatusUpdatesChan: ctor.StatusUpdatesChan,
 		UOM:               ctor.UOM,
-		processor:         newDeviceProcessor(ctor.DeviceType, ctor.RawConfig),
+		processor:         newDeviceProcessor(ctor.DeviceType, ctor.RawConfig, loadData.DeviceEventChan),
 		RawConfig:         ctor.RawConfig,
 	}
 
@@ -121,6 +122,16 @@ func getExpectedType(deviceType enums.DeviceType) (reflect.Type, error) {
 		return device.TypeVacuum, nil
 	case enums.DevCamera:
 		return device.TypeCamera, nil
+	case enums.DevThermostat:
+		return device.TypeThermostat, nil
+	case enums.DevLock:
+		return device.TypeLock, nil
+	case enums.DevCover:
+		return device.TypeCover, nil
+	case enums.DevMediaPlayer:
+		return device.TypeMediaPlayer, nil
+	case enums.DevFan:
+		return device.TypeFan, nil
 	}
 
 	return nil, &ErrUnknownDeviceType{}
@@ -141,6 +152,16 @@ func loadDevice(deviceInterface interface{}, deviceType enums.DeviceType) (inter
 		return deviceInterface.(device.IVacuum).Load()
 	case enums.DevCamera:
 		return deviceInterface.(device.ICamera).Load()
+	case enums.DevThermostat:
+		return deviceInterface.(device.IThermostat).Load()
+	case enums.DevLock:
+		return deviceInterface.(device.ILock).Load()
+	case enums.DevCover:
+		return deviceInterface.(device.ICover).Load()
+	case enums.DevMediaPlayer:
+		return deviceInterface.(device.IMediaPlayer).Load()
+	case enums.DevFan:
+		return deviceInterface.(device.IFan).Load()
 	}
 
 	return nil, &ErrUnknownDeviceType{}
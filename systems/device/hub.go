@@ -22,7 +22,9 @@ func loadHub(ctor *ConstructDevice, pluginLogger common.IPluginLoggerProvider) (
 		Secret:                ctor.Settings.Secrets(),
 		UOM:                   ctor.UOM,
 		DeviceDiscoveredChan:  make(chan *device.DiscoveredDevices, 3),
+		DeviceRemovedChan:     make(chan *device.RemovedDevice, 3),
 		DeviceStateUpdateChan: make(chan *device.StateUpdateData, 10),
+		DeviceEventChan:       make(chan *device.EventData, 10),
 	}
 
 	pluginLoadRequest := &providers.PluginLoadRequest{
@@ -75,7 +77,9 @@ func loadHub(ctor *ConstructDevice, pluginLogger common.IPluginLoggerProvider) (
 			Secret:                ctor.Settings.Secrets(),
 			UOM:                   ctor.UOM,
 			DeviceDiscoveredChan:  loadData.DeviceDiscoveredChan,
+			DeviceRemovedChan:     loadData.DeviceRemovedChan,
 			DeviceStateUpdateChan: make(chan *device.StateUpdateData, 10),
+			DeviceEventChan:       make(chan *device.EventData, 10),
 		}
 
 		dev, ok := v.Interface.(device.IDevice)
@@ -118,7 +122,7 @@ func loadHub(ctor *ConstructDevice, pluginLogger common.IPluginLoggerProvider) (
 			DiscoveryChan:     ctor.DiscoveryChan,
 			StatusUpdatesChan: ctor.StatusUpdatesChan,
 			UOM:               ctor.UOM,
-			processor:         newDeviceProcessor(v.Type, ctor.RawConfig),
+			processor:         newDeviceProcessor(v.Type, ctor.RawConfig, subLoadData.DeviceEventChan),
 			RawConfig:         ctor.RawConfig,
 		}
 
@@ -126,6 +130,8 @@ func loadHub(ctor *ConstructDevice, pluginLogger common.IPluginLoggerProvider) (
 		if nil == w {
 			continue
 		}
+
+		hubWrapper.(*deviceWrapper).addChild(w)
 		wrappers = append(wrappers, w)
 	}
 
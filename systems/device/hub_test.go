@@ -0,0 +1,104 @@
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Fake hub plugin.
+type fakeHub struct {
+	removed []*device.RemovedDevice
+	blocker chan bool
+}
+
+func (*fakeHub) Init(*device.InitDataDevice) error {
+	return nil
+}
+
+func (*fakeHub) Unload() {
+}
+
+func (*fakeHub) GetName() string {
+	return "hub"
+}
+
+func (*fakeHub) GetSpec() *device.Spec {
+	return &device.Spec{}
+}
+
+func (*fakeHub) Load() (*device.HubLoadResult, error) {
+	return &device.HubLoadResult{
+		State: &device.HubState{NumDevices: 1},
+		Devices: []*device.DiscoveredDevices{
+			{
+				Type:      enums.DevLight,
+				Interface: &fakeLight{},
+				State:     &device.LightState{On: true},
+			},
+		},
+	}, nil
+}
+
+func (f *fakeHub) Update() (*device.HubLoadResult, error) {
+	if nil != f.blocker {
+		<-f.blocker
+	}
+
+	return &device.HubLoadResult{
+		State:   &device.HubState{NumDevices: 0},
+		Removed: f.removed,
+	}, nil
+}
+
+// Tests removal of the device, reported by hub.
+func TestHubRemovedDevice(t *testing.T) {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	h := &fakeHub{}
+	settings.(mocks.IFakeSettings).AddLoader(h)
+
+	updates := make(chan *UpdateEvent, 10)
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevHub,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: updates,
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	require.Equal(t, 2, len(wrappers), "wrappers")
+
+	hub := wrappers[0].(*deviceWrapper)
+	defer hub.Unload()
+	assert.Equal(t, 1, len(hub.children), "children")
+
+	h.removed = []*device.RemovedDevice{{Type: enums.DevSwitch, Name: "fake light"}}
+	hub.pullHubUpdate()
+	assert.Equal(t, 1, len(hub.children), "wrong type")
+
+	h.removed = []*device.RemovedDevice{{Type: enums.DevLight, Name: "fake light"}}
+	hub.pullHubUpdate()
+	assert.Equal(t, 0, len(hub.children), "not removed")
+	assert.True(t, wrappers[1].(*deviceWrapper).stopped, "not unloaded")
+
+	for {
+		select {
+		case e := <-updates:
+			if !e.Removed {
+				continue
+			}
+
+			assert.Equal(t, "test.light.fake_light", e.ID, "removed id")
+			return
+		case <-time.After(1 * time.Second):
+			assert.Fail(t, "removal was not reported")
+			return
+		}
+	}
+}
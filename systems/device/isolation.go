@@ -0,0 +1,143 @@
+package device
+
+import (
+	"fmt"
+	"time"
+
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Default plugin call deadline.
+	defaultCallTimeout = 30 * time.Second
+	// Default number of consecutive errors after which device is faulted.
+	defaultMaxErrors = 10
+)
+
+// Plugin calls isolation settings, loaded from device config.
+// Timeout is in seconds.
+type isolationSettings struct {
+	CallTimeout   int  `yaml:"callTimeout"`
+	MaxErrors     int  `yaml:"maxErrors"`
+	ReloadFaulted bool `yaml:"reloadFaulted"`
+}
+
+// Constructs isolation settings.
+func newIsolationSettings(rawConfig string) *isolationSettings {
+	s := &isolationSettings{}
+	err := yaml.Unmarshal([]byte(rawConfig), s)
+	if err != nil {
+		s = &isolationSettings{}
+	}
+
+	if s.CallTimeout <= 0 {
+		s.CallTimeout = int(defaultCallTimeout.Seconds())
+	}
+
+	if s.MaxErrors <= 0 {
+		s.MaxErrors = defaultMaxErrors
+	}
+
+	return s
+}
+
+// Plugin call result, passed from the calling goroutine.
+type pluginCallResult struct {
+	result interface{}
+	err    error
+}
+
+// Calls plugin, recovering from panics and limiting execution time.
+// If deadline is reached, call keeps running in background and its results are ignored.
+// Results are passed back through the channel, so late call doesn't touch caller's variables.
+func (w *deviceWrapper) safeCall(f func() (interface{}, error)) (interface{}, error) {
+	done := make(chan *pluginCallResult, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &pluginCallResult{err: &ErrPluginPanic{Message: fmt.Sprint(r)}}
+			}
+		}()
+
+		result, err := f()
+		done <- &pluginCallResult{result: result, err: err}
+	}()
+
+	timer := time.NewTimer(time.Duration(w.isolation.CallTimeout) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case r := <-done:
+		return r.result, r.err
+	case <-timer.C:
+		return nil, &ErrPluginTimeout{}
+	}
+}
+
+// Registers plugin call result.
+// Device is faulted after configured number of consecutive errors.
+func (w *deviceWrapper) callResult(err error) {
+	w.updateMutex.Lock()
+	if nil == err {
+		w.callErrors = 0
+		w.updateMutex.Unlock()
+		return
+	}
+
+	w.callErrors++
+	faulted := !w.faulted && w.callErrors >= w.isolation.MaxErrors
+	if faulted {
+		w.faulted = true
+	}
+	w.updateMutex.Unlock()
+
+	if faulted {
+		w.logger.Error("Device is faulted after too many errors", err)
+		w.setUnavailable()
+	}
+}
+
+// Checks whether device is faulted.
+func (w *deviceWrapper) isFaulted() bool {
+	w.updateMutex.Lock()
+	defer w.updateMutex.Unlock()
+
+	return w.faulted
+}
+
+// Re-initializes faulted device plugin.
+// Hubs are not reloaded, since it would duplicate discovered devices.
+func (w *deviceWrapper) reload() {
+	if enums.DevHub == w.Ctor.DeviceType {
+		w.logger.Warn("Hub can't be reloaded")
+		return
+	}
+
+	w.Lock()
+	w.logger.Info("Reloading faulted device")
+
+	state, err := w.safeCall(func() (interface{}, error) {
+		w.Ctor.DeviceInterface.(device.IDevice).Unload()
+		err := w.Ctor.DeviceInterface.(device.IDevice).Init(w.Ctor.LoadData)
+		if err != nil {
+			return nil, err
+		}
+
+		return loadDevice(w.Ctor.DeviceInterface, w.Ctor.DeviceType)
+	})
+	w.Unlock()
+
+	if nil != err {
+		w.logger.Error("Failed to reload device", err)
+		return
+	}
+
+	w.updateMutex.Lock()
+	w.faulted = false
+	w.callErrors = 0
+	w.updateMutex.Unlock()
+
+	w.processUpdate(state)
+}
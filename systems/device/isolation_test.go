@@ -0,0 +1,155 @@
+package device
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Fake switch plugin with failing calls.
+type fakeFaultySwitch struct {
+	fakeSwitch
+	inits   int
+	ons     int
+	panics  bool
+	blocker chan bool
+}
+
+func (f *fakeFaultySwitch) Init(*device.InitDataDevice) error {
+	f.inits++
+	return nil
+}
+
+func (f *fakeFaultySwitch) On() error {
+	if f.panics {
+		panic("on")
+	}
+
+	f.ons++
+	return nil
+}
+
+func (f *fakeFaultySwitch) Update() (*device.SwitchState, error) {
+	if nil != f.blocker {
+		<-f.blocker
+	}
+
+	return f.state, f.err
+}
+
+// Tests isolation settings.
+func TestIsolationSettings(t *testing.T) {
+	s := newIsolationSettings("callTimeout: 5\nmaxErrors: 2\nreloadFaulted: true")
+	assert.Equal(t, &isolationSettings{CallTimeout: 5, MaxErrors: 2, ReloadFaulted: true}, s)
+
+	s = newIsolationSettings("callTimeout: [")
+	assert.Equal(t, int(defaultCallTimeout.Seconds()), s.CallTimeout, "default timeout")
+	assert.Equal(t, defaultMaxErrors, s.MaxErrors, "default errors")
+}
+
+// Tests that plugin panic doesn't affect the worker.
+func TestPluginPanic(t *testing.T) {
+	s := &fakeFaultySwitch{fakeSwitch: fakeSwitch{state: &device.SwitchState{On: true}}, panics: true}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, 1, w.callErrors, "errors")
+	assert.Equal(t, 0, s.ons, "invoked")
+
+	s.panics = false
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, 0, w.callErrors, "errors reset")
+	assert.Equal(t, 1, s.ons, "invoked")
+}
+
+// Tests plugin call deadline.
+func TestPluginTimeout(t *testing.T) {
+	s := &fakeFaultySwitch{fakeSwitch: fakeSwitch{
+		state: &device.SwitchState{On: true},
+		spec: &device.Spec{
+			SupportedProperties: []enums.Property{enums.PropOn},
+			UpdatePeriod:        time.Hour,
+		},
+	}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+	w.isolation.CallTimeout = 1
+
+	s.blocker = make(chan bool)
+	defer close(s.blocker)
+
+	start := time.Now()
+	w.pullDeviceUpdate()
+	assert.True(t, time.Since(start) < 2*time.Second, "deadline")
+	assert.Equal(t, 1, w.callErrors, "errors")
+}
+
+// Tests that late hub update is ignored after deadline.
+func TestHubUpdateTimeout(t *testing.T) {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	h := &fakeHub{blocker: make(chan bool)}
+	settings.(mocks.IFakeSettings).AddLoader(h)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevHub,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	hub := wrappers[0].(*deviceWrapper)
+	defer hub.Unload()
+	hub.isolation.CallTimeout = 1
+
+	h.removed = []*device.RemovedDevice{{Type: enums.DevLight, Name: "fake light"}}
+	start := time.Now()
+	hub.pullHubUpdate()
+	assert.True(t, time.Since(start) < 2*time.Second, "deadline")
+	assert.Equal(t, 1, hub.callErrors, "errors")
+
+	h.blocker <- true
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, len(hub.children), "late update applied")
+}
+
+// Tests that device is faulted after too many errors and reloaded.
+func TestFaultedReload(t *testing.T) {
+	s := &fakeFaultySwitch{fakeSwitch: fakeSwitch{
+		state: &device.SwitchState{On: true},
+		spec: &device.Spec{
+			SupportedCommands:   []enums.Command{enums.CmdOn},
+			SupportedProperties: []enums.Property{enums.PropOn},
+			UpdatePeriod:        time.Hour,
+		},
+	}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+	w.isolation.MaxErrors = 2
+
+	s.err = errors.New("failed")
+	w.pullDeviceUpdate()
+	assert.False(t, w.isFaulted(), "not faulted")
+	w.pullDeviceUpdate()
+	assert.True(t, w.isFaulted(), "faulted")
+	assert.True(t, w.GetUpdateMessage().Unavailable, "unavailable")
+
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, 0, s.ons, "faulted command")
+
+	s.err = nil
+	w.isolation.ReloadFaulted = true
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, 1, s.inits, "reloaded")
+	assert.False(t, w.isFaulted(), "not faulted after reload")
+	assert.False(t, w.GetUpdateMessage().Unavailable, "available after reload")
+	assert.Equal(t, 1, s.ons, "command after reload")
+}
@@ -0,0 +1,393 @@
+package device
+
+import (
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Rounds numeric property to the precision.
+	stageRound = "round"
+	// Suppresses changes smaller than the threshold.
+	stageDeadband = "deadband"
+	// Averages last window values.
+	stageSmooth = "smooth"
+	// Limits numeric property to min..max range.
+	stageClamp = "clamp"
+	// Negates boolean property or mirrors numeric one within min..max range.
+	stageInvert = "invert"
+	// Converts numeric property from the unit system reported by plugin.
+	stageUnit = "unit"
+	// Drops property entirely.
+	stageDrop = "drop"
+
+	// Default range of the inverted numeric property.
+	defaultInvertMax = 100
+)
+
+// Processor pipeline settings, loaded from device config.
+type pipelineSettings struct {
+	Processors []*stageSettings `yaml:"processors"`
+}
+
+// Single pipeline stage settings.
+type stageSettings struct {
+	Type       string   `yaml:"type"`
+	Properties []string `yaml:"properties"`
+	Devices    []string `yaml:"devices"`
+	Precision  int      `yaml:"precision"`
+	Threshold  float64  `yaml:"threshold"`
+	Window     int      `yaml:"window"`
+	Min        *float64 `yaml:"min"`
+	Max        *float64 `yaml:"max"`
+	Unit       string   `yaml:"unit"`
+}
+
+// Single pipeline stage.
+type pipelineStage struct {
+	settings   *stageSettings
+	properties []enums.Property
+	unit       enums.UOM
+
+	last    map[enums.Property]interface{}
+	history map[enums.Property][]float64
+}
+
+// Post-processor with user-defined stages.
+type pipelineProcessor struct {
+	sync.Mutex
+
+	stages []*pipelineStage
+	uom    enums.UOM
+}
+
+// Constructs a new pipeline processor for the device.
+// Returns nil if no stages are configured for the device.
+func newPipelineProcessor(rawConfig string, deviceID string, uom enums.UOM,
+	logger common.ILoggerProvider) IProcessor {
+	s := &pipelineSettings{}
+	err := yaml.Unmarshal([]byte(rawConfig), s)
+	if err != nil || 0 == len(s.Processors) {
+		return nil
+	}
+
+	p := &pipelineProcessor{
+		stages: make([]*pipelineStage, 0),
+		uom:    uom,
+	}
+
+	for _, v := range s.Processors {
+		if nil == v {
+			continue
+		}
+
+		stage, err := newPipelineStage(v, deviceID)
+		if err != nil {
+			logger.Warn("Skipping wrong processor stage", common.LogErrorToken, err.Error(), "stage", v.Type)
+			continue
+		}
+
+		if nil != stage {
+			p.stages = append(p.stages, stage)
+		}
+	}
+
+	if 0 == len(p.stages) {
+		return nil
+	}
+
+	return p
+}
+
+// Constructs a new pipeline stage.
+// Returns nil if stage is not applicable for the device.
+func newPipelineStage(s *stageSettings, deviceID string) (*pipelineStage, error) {
+	if 0 != len(s.Devices) {
+		matched := false
+		for _, v := range s.Devices {
+			exp, err := glob.Compile(v)
+			if err != nil {
+				return nil, &ErrWrongProcessorStage{Reason: "wrong device selector " + v}
+			}
+
+			if exp.Match(deviceID) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return nil, nil
+		}
+	}
+
+	stage := &pipelineStage{
+		settings:   s,
+		properties: make([]enums.Property, 0),
+		last:       make(map[enums.Property]interface{}),
+		history:    make(map[enums.Property][]float64),
+	}
+
+	for _, v := range s.Properties {
+		prop, err := enums.PropertyString(v)
+		if err != nil {
+			return nil, &ErrWrongProcessorStage{Reason: "unknown property " + v}
+		}
+
+		stage.properties = append(stage.properties, prop)
+	}
+
+	if 0 == len(stage.properties) {
+		return nil, &ErrWrongProcessorStage{Reason: "no properties"}
+	}
+
+	switch s.Type {
+	case stageRound:
+		if s.Precision < 0 {
+			return nil, &ErrWrongProcessorStage{Reason: "negative precision"}
+		}
+	case stageDeadband:
+		if s.Threshold <= 0 {
+			return nil, &ErrWrongProcessorStage{Reason: "threshold should be positive"}
+		}
+	case stageSmooth:
+		if s.Window < 2 {
+			return nil, &ErrWrongProcessorStage{Reason: "window should be at least 2"}
+		}
+	case stageClamp:
+		if nil == s.Min && nil == s.Max || nil != s.Min && nil != s.Max && *s.Min > *s.Max {
+			return nil, &ErrWrongProcessorStage{Reason: "wrong range"}
+		}
+	case stageUnit:
+		uom, err := enums.UOMString(s.Unit)
+		if err != nil {
+			return nil, &ErrWrongProcessorStage{Reason: "unknown unit " + s.Unit}
+		}
+		stage.unit = uom
+	case stageInvert, stageDrop:
+	default:
+		return nil, &ErrWrongProcessorStage{Reason: "unknown type"}
+	}
+
+	return stage, nil
+}
+
+// IsExtraProperty returns false, since pipeline doesn't add properties.
+func (p *pipelineProcessor) IsExtraProperty(enums.Property) bool {
+	return false
+}
+
+// GetExtraSupportPropertiesSpec returns empty list.
+func (p *pipelineProcessor) GetExtraSupportPropertiesSpec() []enums.Property {
+	return []enums.Property{}
+}
+
+// IsPropertyGood passes property through all matching stages.
+// Property is removed from the device state if one of the stages drops it.
+func (p *pipelineProcessor) IsPropertyGood(prop enums.Property,
+	val interface{}) (bool, map[enums.Property]interface{}) {
+	p.Lock()
+	defer p.Unlock()
+
+	for _, v := range p.stages {
+		if !enums.SliceContainsProperty(v.properties, prop) {
+			continue
+		}
+
+		var ok bool
+		val, ok = v.process(prop, val, p.uom)
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, map[enums.Property]interface{}{prop: val}
+}
+
+// Processes a single value.
+func (s *pipelineStage) process(prop enums.Property, val interface{}, uom enums.UOM) (interface{}, bool) {
+	if stageDrop == s.settings.Type {
+		return nil, false
+	}
+
+	if b, ok := val.(bool); ok {
+		if stageInvert == s.settings.Type {
+			return !b, true
+		}
+
+		return val, true
+	}
+
+	f, ok := toFloat(val)
+	if !ok {
+		return val, true
+	}
+
+	switch s.settings.Type {
+	case stageRound:
+		pow := math.Pow(10, float64(s.settings.Precision))
+		f = math.Round(f*pow) / pow
+	case stageDeadband:
+		return s.deadband(prop, f, val)
+	case stageSmooth:
+		f = s.smooth(prop, f)
+	case stageClamp:
+		if nil != s.settings.Min {
+			f = math.Max(f, *s.settings.Min)
+		}
+
+		if nil != s.settings.Max {
+			f = math.Min(f, *s.settings.Max)
+		}
+	case stageInvert:
+		lo, hi := 0.0, float64(defaultInvertMax)
+		if nil != s.settings.Min {
+			lo = *s.settings.Min
+		}
+
+		if nil != s.settings.Max {
+			hi = *s.settings.Max
+		}
+
+		f = lo + hi - f
+	case stageUnit:
+		f = helpers.UOMConvertInterface(f, prop, s.unit, uom).(float64)
+	}
+
+	return fromFloat(f, val), true
+}
+
+// Returns previously passed value if the change is smaller than the threshold.
+func (s *pipelineStage) deadband(prop enums.Property, f float64, val interface{}) (interface{}, bool) {
+	if last, ok := s.last[prop]; ok {
+		prev, _ := toFloat(last)
+		if math.Abs(f-prev) < s.settings.Threshold {
+			return last, true
+		}
+	}
+
+	s.last[prop] = val
+	return val, true
+}
+
+// Returns moving average of the last values.
+func (s *pipelineStage) smooth(prop enums.Property, f float64) float64 {
+	history := append(s.history[prop], f)
+	if len(history) > s.settings.Window {
+		history = history[len(history)-s.settings.Window:]
+	}
+	s.history[prop] = history
+
+	sum := 0.0
+	for _, v := range history {
+		sum += v
+	}
+
+	return sum / float64(len(history))
+}
+
+// Converts numeric value to float.
+func toFloat(val interface{}) (float64, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	}
+
+	return 0, false
+}
+
+// Converts float back to the type of original value.
+// Integer values are rounded and negative values are not allowed for unsigned ones.
+func fromFloat(f float64, original interface{}) interface{} {
+	rt := reflect.TypeOf(original)
+	switch rt.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return reflect.ValueOf(f).Convert(rt).Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = math.Max(f, 0)
+	}
+
+	return reflect.ValueOf(math.Round(f)).Convert(rt).Interface()
+}
+
+// Processors chain, e.g. camera processor followed by user-defined pipeline.
+type processorChain []IProcessor
+
+// Combines processors into a chain, nil processors are skipped.
+func newProcessorChain(processors ...IProcessor) IProcessor {
+	chain := make(processorChain, 0)
+	for _, v := range processors {
+		if nil != v {
+			chain = append(chain, v)
+		}
+	}
+
+	switch len(chain) {
+	case 0:
+		return nil
+	case 1:
+		return chain[0]
+	}
+
+	return chain
+}
+
+// IsExtraProperty checks whether any of processors adds the property.
+func (c processorChain) IsExtraProperty(prop enums.Property) bool {
+	for _, v := range c {
+		if v.IsExtraProperty(prop) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetExtraSupportPropertiesSpec returns extra properties of all processors.
+func (c processorChain) GetExtraSupportPropertiesSpec() []enums.Property {
+	props := make([]enums.Property, 0)
+	for _, v := range c {
+		props = append(props, v.GetExtraSupportPropertiesSpec()...)
+	}
+
+	return props
+}
+
+// IsPropertyGood passes property through all processors.
+// Results of the previous processor are used as input of the next one.
+func (c processorChain) IsPropertyGood(prop enums.Property, val interface{}) (bool, map[enums.Property]interface{}) {
+	props := map[enums.Property]interface{}{prop: val}
+	for _, p := range c {
+		next := make(map[enums.Property]interface{}, len(props))
+		for k, v := range props {
+			ok, res := p.IsPropertyGood(k, v)
+			if !ok {
+				if k == prop {
+					return false, nil
+				}
+
+				continue
+			}
+
+			for rk, rv := range res {
+				next[rk] = rv
+			}
+		}
+
+		props = next
+	}
+
+	return true, props
+}
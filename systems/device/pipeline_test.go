@@ -0,0 +1,184 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Tests pipeline stages.
+func TestPipelineStages(t *testing.T) {
+	data := []struct {
+		config string
+		prop   enums.Property
+		in     []interface{}
+		out    []interface{}
+	}{
+		{
+			config: "processors:\n  - type: round\n    properties: [temperature]\n    precision: 1",
+			prop:   enums.PropTemperature,
+			in:     []interface{}{21.44, 21.46, 20},
+			out:    []interface{}{21.4, 21.5, 20},
+		},
+		{
+			config: "processors:\n  - type: deadband\n    properties: [temperature]\n    threshold: 0.5",
+			prop:   enums.PropTemperature,
+			in:     []interface{}{21.0, 21.3, 20.6, 21.6, 21.2},
+			out:    []interface{}{21.0, 21.0, 21.0, 21.6, 21.6},
+		},
+		{
+			config: "processors:\n  - type: smooth\n    properties: [humidity]\n    window: 3",
+			prop:   enums.PropHumidity,
+			in:     []interface{}{30.0, 60.0, 60.0, 90.0},
+			out:    []interface{}{30.0, 45.0, 50.0, 70.0},
+		},
+		{
+			config: "processors:\n  - type: clamp\n    properties: [battery_level]\n    min: 5\n    max: 100",
+			prop:   enums.PropBatteryLevel,
+			in:     []interface{}{uint8(0), uint8(50), uint8(120)},
+			out:    []interface{}{uint8(5), uint8(50), uint8(100)},
+		},
+		{
+			config: "processors:\n  - type: invert\n    properties: [on, position]",
+			prop:   enums.PropOn,
+			in:     []interface{}{true, false, "test"},
+			out:    []interface{}{false, true, "test"},
+		},
+		{
+			config: "processors:\n  - type: invert\n    properties: [on, position]",
+			prop:   enums.PropPosition,
+			in:     []interface{}{uint8(0), uint8(30)},
+			out:    []interface{}{uint8(100), uint8(70)},
+		},
+		{
+			config: "processors:\n  - type: unit\n    properties: [temperature]\n    unit: imperial",
+			prop:   enums.PropTemperature,
+			in:     []interface{}{212.0},
+			out:    []interface{}{100.0},
+		},
+		{
+			config: "processors:\n  - type: drop\n    properties: [humidity]",
+			prop:   enums.PropTemperature,
+			in:     []interface{}{10.0},
+			out:    []interface{}{10.0},
+		},
+		{
+			config: "processors:\n  - type: smooth\n    properties: [temperature]\n    window: 2\n" +
+				"  - type: round\n    properties: [temperature]\n  - type: deadband\n    properties: [temperature]\n" +
+				"    threshold: 2",
+			prop: enums.PropTemperature,
+			in:   []interface{}{20.0, 21.0, 22.0, 25.0},
+			out:  []interface{}{20.0, 20.0, 22.0, 24.0},
+		},
+	}
+
+	for _, v := range data {
+		p := newPipelineProcessor(v.config, "test.sensor.test", enums.UOMMetric, mocks.FakeNewLogger(nil))
+		require.NotNil(t, p, v.config)
+
+		for i, in := range v.in {
+			ok, res := p.IsPropertyGood(v.prop, in)
+			require.True(t, ok, "%s: %v", v.config, in)
+			if f, isFloat := v.out[i].(float64); isFloat {
+				assert.InDelta(t, f, res[v.prop], 0.001, "%s: %v", v.config, in)
+				continue
+			}
+
+			assert.Equal(t, v.out[i], res[v.prop], "%s: %v", v.config, in)
+		}
+	}
+
+	p := newPipelineProcessor("processors:\n  - type: drop\n    properties: [humidity]",
+		"test.sensor.test", enums.UOMMetric, mocks.FakeNewLogger(nil))
+	ok, _ := p.IsPropertyGood(enums.PropHumidity, 10.0)
+	assert.False(t, ok, "drop")
+}
+
+// Tests wrong pipeline settings.
+func TestPipelineWrongSettings(t *testing.T) {
+	data := []string{
+		"",
+		"processors: wrong",
+		"processors:\n  - type: unknown\n    properties: [temperature]",
+		"processors:\n  - type: round\n    properties: [wrong]",
+		"processors:\n  - type: round",
+		"processors:\n  - type: round\n    properties: [temperature]\n    precision: -1",
+		"processors:\n  - type: deadband\n    properties: [temperature]",
+		"processors:\n  - type: smooth\n    properties: [temperature]\n    window: 1",
+		"processors:\n  - type: clamp\n    properties: [temperature]",
+		"processors:\n  - type: clamp\n    properties: [temperature]\n    min: 10\n    max: 5",
+		"processors:\n  - type: unit\n    properties: [temperature]\n    unit: wrong",
+		"processors:\n  - type: drop\n    properties: [temperature]\n    devices: [\"[\"]",
+		"processors:\n  - type: drop\n    properties: [temperature]\n    devices: [other.*]",
+	}
+
+	for _, v := range data {
+		assert.Nil(t, newPipelineProcessor(v, "test.sensor.test", enums.UOMMetric, mocks.FakeNewLogger(nil)), v)
+	}
+}
+
+// Tests pipeline applied by the device wrapper.
+func TestPipelineWrapper(t *testing.T) {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	s := &fakeSwitch{state: &device.SwitchState{On: true, Power: 10}}
+	settings.(mocks.IFakeSettings).AddLoader(s)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName: "fake",
+		DeviceType: enums.DevSwitch,
+		ConfigName: "test",
+		Settings:   settings,
+		RawConfig: `
+processors:
+  - type: deadband
+    properties: [power]
+    threshold: 5
+  - type: invert
+    properties: [on]
+    devices: [test.switch.*]
+`,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	w := wrappers[0].(*deviceWrapper)
+	defer w.Unload()
+
+	msg := w.GetUpdateMessage()
+	assert.Equal(t, map[string]interface{}{"on": false, "power": float64(10)}, msg.State, "first state")
+
+	w.setState(&device.SwitchState{On: true, Power: 12})
+	assert.Nil(t, w.GetUpdateMessage(), "deadband")
+
+	w.setState(&device.SwitchState{On: false, Power: 20})
+	msg = w.GetUpdateMessage()
+	assert.Equal(t, map[string]interface{}{"on": true, "power": float64(20)}, msg.State, "delta")
+}
+
+// Tests processors chain.
+func TestProcessorChain(t *testing.T) {
+	logger := mocks.FakeNewLogger(nil)
+	assert.Nil(t, newProcessorChain(nil, nil), "empty")
+
+	round := newPipelineProcessor("processors:\n  - type: round\n    properties: [distance]",
+		"test.camera.test", enums.UOMMetric, logger)
+	assert.Equal(t, round, newProcessorChain(nil, round), "single")
+
+	drop := newPipelineProcessor("processors:\n  - type: drop\n    properties: [distance]",
+		"test.camera.test", enums.UOMMetric, logger)
+	c := newProcessorChain(newCameraProcessor("", nil), round)
+	assert.True(t, c.IsExtraProperty(enums.PropDistance), "extra property")
+	assert.Equal(t, []enums.Property{enums.PropDistance}, c.GetExtraSupportPropertiesSpec(), "extra spec")
+
+	ok, res := newProcessorChain(round, drop).IsPropertyGood(enums.PropDistance, 10.4)
+	assert.False(t, ok, "dropped")
+	assert.Nil(t, res, "dropped result")
+
+	ok, res = newProcessorChain(round, drop).IsPropertyGood(enums.PropOn, true)
+	assert.True(t, ok, "passed")
+	assert.Equal(t, true, res[enums.PropOn], "passed result")
+}
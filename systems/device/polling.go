@@ -0,0 +1,132 @@
+package device
+
+import (
+	"math/rand"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Default duration of faster polling after a command.
+	defaultFastPollingWindow = 10 * time.Second
+	// Maximum polling interval while device keeps failing.
+	maxPollingBackoff = 10 * time.Minute
+)
+
+// Polling settings, loaded from device config.
+// Both values are in seconds, fast polling is disabled by default.
+type pollingSettings struct {
+	FastPolling       int `yaml:"fastPolling"`
+	FastPollingWindow int `yaml:"fastPollingWindow"`
+}
+
+// Constructs polling settings.
+func newPollingSettings(rawConfig string) *pollingSettings {
+	s := &pollingSettings{}
+	err := yaml.Unmarshal([]byte(rawConfig), s)
+	if err != nil || s.FastPolling < 0 {
+		s = &pollingSettings{}
+	}
+
+	if s.FastPollingWindow <= 0 {
+		s.FastPollingWindow = int(defaultFastPollingWindow.Seconds())
+	}
+
+	return s
+}
+
+// Calls for updates.
+// First poll is delayed by a random offset, so devices are not polled in lockstep.
+func (w *deviceWrapper) periodicUpdates(duration time.Duration) {
+	if !w.isPolling {
+		return
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(duration))))
+	for {
+		select {
+		case <-w.stopChan:
+			timer.Stop()
+			return
+		case <-w.pollReset:
+			timer.Stop()
+			timer = time.NewTimer(w.nextPollDelay())
+		case <-timer.C:
+			if w.isFaulted() {
+				if w.isolation.ReloadFaulted {
+					w.reload()
+				}
+			} else {
+				w.checkAvailability()
+				w.pollTick()
+			}
+
+			timer.Reset(w.nextPollDelay())
+		}
+	}
+}
+
+// Starts a new poll, unless previous one is still in flight.
+func (w *deviceWrapper) pollTick() {
+	w.updateMutex.Lock()
+	if w.pollInFlight {
+		w.updateMutex.Unlock()
+		w.logger.Debug("Previous poll is still in flight, skipping")
+		return
+	}
+
+	w.pollInFlight = true
+	w.updateMutex.Unlock()
+
+	go func() {
+		w.pullUpdate()
+
+		w.updateMutex.Lock()
+		w.pollInFlight = false
+		w.updateMutex.Unlock()
+	}()
+}
+
+// Returns delay before the next poll.
+// Delay grows exponentially while device fails and shrinks after a command, if fast polling is enabled.
+func (w *deviceWrapper) nextPollDelay() time.Duration {
+	w.updateMutex.Lock()
+	defer w.updateMutex.Unlock()
+
+	delay := w.Spec.UpdatePeriod
+	if w.failures > 0 {
+		for ii := 0; ii < w.failures && delay < maxPollingBackoff; ii++ {
+			delay *= 2
+		}
+
+		if delay > maxPollingBackoff && w.Spec.UpdatePeriod < maxPollingBackoff {
+			delay = maxPollingBackoff
+		}
+
+		return delay
+	}
+
+	fast := time.Duration(w.polling.FastPolling) * time.Second
+	if fast > 0 && fast < delay && time.Now().Before(w.fastPollUntil) {
+		return fast
+	}
+
+	return delay
+}
+
+// Enables faster polling for a short window after a command.
+func (w *deviceWrapper) startFastPolling() {
+	if !w.isPolling || 0 == w.polling.FastPolling {
+		return
+	}
+
+	w.updateMutex.Lock()
+	w.fastPollUntil = time.Now().Add(time.Duration(w.polling.FastPollingWindow) * time.Second)
+	w.updateMutex.Unlock()
+
+	select {
+	case w.pollReset <- true:
+	default:
+	}
+}
@@ -0,0 +1,110 @@
+package device
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
+
+// Loads fake switch wrapper with a long polling period.
+func getFakePollingWrapper(t *testing.T, period time.Duration) *deviceWrapper {
+	return getFakeSwitchWrapper(t, &fakeSwitch{
+		state: &device.SwitchState{On: true},
+		spec: &device.Spec{
+			SupportedCommands:   []enums.Command{enums.CmdOn},
+			SupportedProperties: []enums.Property{enums.PropOn},
+			UpdatePeriod:        period,
+		},
+	})
+}
+
+// Tests polling settings.
+func TestPollingSettings(t *testing.T) {
+	s := newPollingSettings("fastPolling: 1\nfastPollingWindow: 5")
+	assert.Equal(t, &pollingSettings{FastPolling: 1, FastPollingWindow: 5}, s)
+
+	s = newPollingSettings("fastPolling: -1")
+	assert.Equal(t, 0, s.FastPolling, "disabled")
+	assert.Equal(t, int(defaultFastPollingWindow.Seconds()), s.FastPollingWindow, "default window")
+}
+
+// Tests exponential backoff while device fails.
+func TestPollingBackoff(t *testing.T) {
+	w := getFakePollingWrapper(t, time.Minute)
+	defer w.Unload()
+
+	data := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{0, time.Minute},
+		{1, 2 * time.Minute},
+		{3, 8 * time.Minute},
+		{5, maxPollingBackoff},
+	}
+
+	for _, v := range data {
+		w.updateMutex.Lock()
+		w.failures = v.failures
+		w.updateMutex.Unlock()
+		assert.Equal(t, v.expected, w.nextPollDelay(), "failures %d", v.failures)
+	}
+}
+
+// Tests faster polling after a command.
+func TestFastPolling(t *testing.T) {
+	w := getFakePollingWrapper(t, time.Hour)
+	defer w.Unload()
+
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, time.Hour, w.nextPollDelay(), "disabled")
+
+	w.polling.FastPolling = 2
+	w.InvokeCommand(enums.CmdOn, nil)
+	assert.Equal(t, 2*time.Second, w.nextPollDelay(), "enabled")
+
+	w.updateMutex.Lock()
+	w.fastPollUntil = time.Now().Add(-time.Second)
+	w.updateMutex.Unlock()
+	assert.Equal(t, time.Hour, w.nextPollDelay(), "expired")
+}
+
+// Tests that tick is skipped while previous poll is in flight.
+func TestPollInFlight(t *testing.T) {
+	w := getFakePollingWrapper(t, time.Hour)
+	defer w.Unload()
+
+	w.updateMutex.Lock()
+	w.pollInFlight = true
+	w.updateMutex.Unlock()
+
+	w.pollTick()
+	time.Sleep(100 * time.Millisecond)
+
+	w.updateMutex.Lock()
+	assert.True(t, w.pollInFlight, "skipped")
+	w.pollInFlight = false
+	w.updateMutex.Unlock()
+
+	w.pollTick()
+	time.Sleep(100 * time.Millisecond)
+
+	w.updateMutex.Lock()
+	assert.False(t, w.pollInFlight, "finished")
+	w.updateMutex.Unlock()
+}
+
+// Tests that early polls use initialized update method.
+func TestPollingStart(t *testing.T) {
+	w := getFakePollingWrapper(t, 10*time.Millisecond)
+	defer w.Unload()
+
+	time.Sleep(100 * time.Millisecond)
+
+	w.updateMutex.Lock()
+	defer w.updateMutex.Unlock()
+	assert.Equal(t, 0, w.failures, "failures")
+}
@@ -1,6 +1,9 @@
 package device
 
-import "go-home.io/x/server/plugins/device/enums"
+import (
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+)
 
 // IProcessor defines device post-processor.
 type IProcessor interface {
@@ -10,10 +13,11 @@ type IProcessor interface {
 }
 
 // Constructs a new device processor if required for the device.
-func newDeviceProcessor(deviceType enums.DeviceType, rawConfig string) IProcessor {
+// Events channel is used by processors, which detect momentary events.
+func newDeviceProcessor(deviceType enums.DeviceType, rawConfig string, events chan *device.EventData) IProcessor {
 	switch deviceType {
 	case enums.DevCamera:
-		return newCameraProcessor(rawConfig)
+		return newCameraProcessor(rawConfig, events)
 	}
 
 	return nil
@@ -24,12 +24,31 @@ type IDeviceWrapperProvider interface {
 	ID() string
 	Name() string
 	InvokeCommand(enums.Command, map[string]interface{})
+	QueueCommand(enums.Command, map[string]interface{})
 	GetUpdateMessage() *bus.DeviceUpdateMessage
+	GetKeyframeMessage() *bus.DeviceUpdateMessage
 }
 
+var (
+	// Maximum interval between full state updates.
+	keyframeInterval = 5 * time.Minute
+	// Maximum number of delta updates between full state updates.
+	keyframeUpdates = 50
+	// Number of consecutive failed polls after which device is unavailable.
+	unavailableFailures = 3
+	// Number of poll periods without any data after which device is unavailable.
+	unavailablePeriods = 3
+)
+
 // UpdateEvent is a type used for updates sent by a device.
+// Removed flag is set when hub no longer serves the device.
+// Event is set for momentary device events, state is not changed in this case.
+// Keyframe flag is set when full state was requested.
 type UpdateEvent struct {
-	ID string
+	ID       string
+	Removed  bool
+	Keyframe bool
+	Event    *device.EventData
 }
 
 // NewDeviceDiscoveredEvent is a type used for discovering a new device.
@@ -75,11 +94,33 @@ type deviceWrapper struct {
 	stopChan     chan bool
 	updateMethod reflect.Value
 	commands     map[enums.Command]reflect.Value
+	emulated     map[enums.Command]enums.Command
 	children     []IDeviceWrapperProvider
+	childMutex   sync.Mutex
 	stopped      bool
 
 	isPolling bool
 	processor IProcessor
+	queue     *commandQueue
+	isolation *isolationSettings
+	polling   *pollingSettings
+
+	updateMutex     sync.Mutex
+	version         uint64
+	sentState       map[string]interface{}
+	deltasSent      int
+	lastKeyframe    time.Time
+	sentUnavailable bool
+
+	unavailable bool
+	failures    int
+	lastData    time.Time
+	faulted     bool
+	callErrors  int
+
+	pollInFlight  bool
+	fastPollUntil time.Time
+	pollReset     chan bool
 }
 
 // NewDeviceWrapper constructs a new device wrapper.
@@ -93,6 +134,11 @@ func NewDeviceWrapper(ctor *wrapperConstruct) IDeviceWrapperProvider {
 		stopped:   false,
 		children:  make([]IDeviceWrapperProvider, 0),
 		logger:    ctor.Logger,
+		lastData:  time.Now(),
+		queue:     newCommandQueue(ctor.RawConfig),
+		isolation: newIsolationSettings(ctor.RawConfig),
+		polling:   newPollingSettings(ctor.RawConfig),
+		pollReset: make(chan bool, 1),
 	}
 
 	w.Spec = ctor.DeviceInterface.(device.IDevice).GetSpec()
@@ -105,6 +151,8 @@ func NewDeviceWrapper(ctor *wrapperConstruct) IDeviceWrapperProvider {
 	}
 
 	w.logger.AddFields(map[string]string{common.LogIDToken: w.ID()})
+	w.processor = newProcessorChain(ctor.processor,
+		newPipelineProcessor(ctor.RawConfig, w.ID(), ctor.UOM, w.logger))
 
 	if nil != w.processor {
 		w.Spec.SupportedProperties = append(w.Spec.SupportedProperties, w.processor.GetExtraSupportPropertiesSpec()...)
@@ -116,13 +164,18 @@ func NewDeviceWrapper(ctor *wrapperConstruct) IDeviceWrapperProvider {
 
 	if w.Spec.UpdatePeriod.Seconds() > 0 {
 		w.isPolling = true
-		go w.periodicUpdates(w.Spec.UpdatePeriod)
 		w.updateMethod = reflect.ValueOf(ctor.DeviceInterface).MethodByName("Update")
 		w.logger.Debug(fmt.Sprintf("Polling rate for the device is %d seconds",
 			int(w.Spec.UpdatePeriod.Seconds())))
 	}
 
 	w.validateDeviceSpec(ctor)
+	w.validateCapabilities()
+
+	// Polling starts only after wrapper is fully initialized, since first poll might happen right away.
+	if w.isPolling {
+		go w.periodicUpdates(w.Spec.UpdatePeriod)
+	}
 
 	if ctor.IsRootDevice {
 		go w.startHubListeners()
@@ -137,9 +190,8 @@ func NewDeviceWrapper(ctor *wrapperConstruct) IDeviceWrapperProvider {
 // ID is normalized and contains config name, provider name and ID returned from actual device.
 func (w *deviceWrapper) ID() string {
 	if w.internalID == "" {
-		w.internalID = fmt.Sprintf("%s.%s.%s", utils.NormalizeDeviceName(w.Ctor.DeviceConfigName),
-			utils.NormalizeDeviceName(w.Ctor.DeviceType.String()),
-			utils.NormalizeDeviceName(w.Ctor.DeviceInterface.(device.IDevice).GetName()))
+		w.internalID = getDeviceID(w.Ctor.DeviceConfigName, w.Ctor.DeviceType,
+			w.Ctor.DeviceInterface.(device.IDevice).GetName())
 	}
 	return w.internalID
 }
@@ -171,25 +223,105 @@ func (w *deviceWrapper) Unload() {
 	w.stopChan <- true
 	w.stopChan <- true
 
-	w.Ctor.DeviceInterface.(device.IDevice).Unload()
+	_, err := w.safeCall(func() (interface{}, error) {
+		w.Ctor.DeviceInterface.(device.IDevice).Unload()
+		return nil, nil
+	})
+	if err != nil {
+		w.logger.Error("Failed to unload device", err)
+	}
+
 	close(w.Ctor.LoadData.DeviceStateUpdateChan)
+	if nil != w.Ctor.LoadData.DeviceEventChan {
+		close(w.Ctor.LoadData.DeviceEventChan)
+	}
 	if w.Ctor.IsRootDevice {
 		close(w.Ctor.LoadData.DeviceDiscoveredChan)
+		if nil != w.Ctor.LoadData.DeviceRemovedChan {
+			close(w.Ctor.LoadData.DeviceRemovedChan)
+		}
 	}
 
 	w.stopped = true
 
-	for _, v := range w.children {
+	w.childMutex.Lock()
+	children := w.children
+	w.children = make([]IDeviceWrapperProvider, 0)
+	w.childMutex.Unlock()
+
+	for _, v := range children {
 		v.Unload()
 	}
 }
 
-// InvokeCommand performs a call to the device provider.
-// This method validates whether device actually reported this operation as supported.
+// InvokeCommand queues a call to the device provider.
+// Queue is processed by the first caller, others are returning immediately.
 func (w *deviceWrapper) InvokeCommand(cmdName enums.Command, param map[string]interface{}) {
+	if !w.queue.push(cmdName, param) {
+		w.logger.Debug("Device command is queued", common.LogDeviceCommandToken, cmdName.String())
+		return
+	}
+
+	w.processCommands()
+}
+
+// QueueCommand queues command without blocking the caller.
+// At most one routine per device processes the queue, so commands keep the order they were queued in.
+func (w *deviceWrapper) QueueCommand(cmdName enums.Command, param map[string]interface{}) {
+	if !w.queue.push(cmdName, param) {
+		w.logger.Debug("Device command is queued", common.LogDeviceCommandToken, cmdName.String())
+		return
+	}
+
+	go w.processCommands()
+}
+
+// Invokes queued commands until queue is empty.
+func (w *deviceWrapper) processCommands() {
+	for c := w.queue.next(); nil != c; c = w.queue.next() {
+		if w.queue.expired(c) {
+			w.logger.Warn("Dropping expired device command", common.LogDeviceCommandToken, c.cmd.String())
+			continue
+		}
+
+		if w.isFaulted() && w.isolation.ReloadFaulted {
+			w.reload()
+		}
+
+		w.invokeCommand(c.cmd, c.params)
+		w.queue.done()
+	}
+}
+
+// Converts command params to the form supported by the plugin and validates enum values.
+func (w *deviceWrapper) adaptCommand(cmdName enums.Command,
+	param map[string]interface{}) (enums.Command, map[string]interface{}, error) {
+	cmdName, param, err := w.adaptColorCommand(cmdName, param)
+	if err != nil {
+		return cmdName, param, err
+	}
+
+	return cmdName, param, validateEnumCommand(cmdName, param)
+}
+
+// Performs a call to the device provider.
+// This method validates whether device actually reported this operation as supported.
+func (w *deviceWrapper) invokeCommand(cmdName enums.Command, param map[string]interface{}) {
 	w.Lock()
 	defer w.Unlock()
 
+	if w.isFaulted() {
+		w.logger.Warn("Device is faulted, ignoring command", common.LogDeviceCommandToken, cmdName.String())
+		return
+	}
+
+	cmdName, param, err := w.adaptCommand(cmdName, param)
+	if err != nil {
+		w.logger.Warn("Received incorrect command params",
+			common.LogDeviceCommandToken, cmdName.String(), common.LogErrorToken, err.Error())
+		return
+	}
+
 	method, ok := w.commands[cmdName]
 	if !ok {
 		w.logger.Warn("Device doesn't support this command", common.LogDeviceCommandToken, cmdName.String())
@@ -198,7 +330,7 @@ func (w *deviceWrapper) InvokeCommand(cmdName enums.Command, param map[string]in
 
 	w.logger.Debug("Invoking device command", common.LogDeviceCommandToken, cmdName.String())
 
-	var results []reflect.Value
+	var params []reflect.Value
 
 	if method.Type().NumIn() > 0 {
 		obj, err := json.Marshal(param)
@@ -227,13 +359,21 @@ func (w *deviceWrapper) InvokeCommand(cmdName enums.Command, param map[string]in
 			val = val.Elem()
 		}
 
-		results = method.Call([]reflect.Value{val})
-	} else {
-		results = method.Call(nil)
+		params = []reflect.Value{val}
 	}
 
-	if len(results) > 0 && results[0].Interface() != nil {
-		w.logger.Error("Got error while invoking device command", results[0].Interface().(error),
+	_, err = w.safeCall(func() (interface{}, error) {
+		results := method.Call(params)
+		if len(results) > 0 && results[0].Interface() != nil {
+			return nil, results[0].Interface().(error)
+		}
+
+		return nil, nil
+	})
+
+	w.callResult(err)
+	if err != nil {
+		w.logger.Error("Got error while invoking device command", err,
 			common.LogDeviceCommandToken, cmdName.String())
 
 		return
@@ -243,17 +383,87 @@ func (w *deviceWrapper) InvokeCommand(cmdName enums.Command, param map[string]in
 	}
 
 	w.pullUpdate()
+	w.startFastPolling()
 }
 
 // GetUpdateMessage constructs device update message.
+// Only changed properties are sent, full state is sent periodically as a keyframe.
+// Keyframe is also sent if some properties were removed, since delta can't express it.
+// Returns nil if nothing has changed since the previous message.
 func (w *deviceWrapper) GetUpdateMessage() *bus.DeviceUpdateMessage {
+	w.updateMutex.Lock()
+	defer w.updateMutex.Unlock()
+
+	if nil == w.sentState || w.deltasSent >= keyframeUpdates || time.Since(w.lastKeyframe) >= keyframeInterval {
+		return w.getKeyframeMessage()
+	}
+
+	for k := range w.sentState {
+		if _, ok := w.State[k]; !ok {
+			return w.getKeyframeMessage()
+		}
+	}
+
+	changed := make(map[string]interface{})
+	for k, v := range w.State {
+		prev, ok := w.sentState[k]
+		if !ok || !reflect.DeepEqual(prev, v) {
+			changed[k] = v
+		}
+	}
+
+	if 0 == len(changed) && w.sentUnavailable == w.unavailable {
+		return nil
+	}
+
+	msg := w.newUpdateMessage()
+	msg.IsDelta = true
+	for k, v := range changed {
+		msg.State[k] = v
+		w.sentState[k] = v
+	}
+
+	w.deltasSent++
+	return msg
+}
+
+// GetKeyframeMessage constructs device update message with the full state.
+func (w *deviceWrapper) GetKeyframeMessage() *bus.DeviceUpdateMessage {
+	w.updateMutex.Lock()
+	defer w.updateMutex.Unlock()
+
+	return w.getKeyframeMessage()
+}
+
+// Constructs full state message and resets delta tracking.
+func (w *deviceWrapper) getKeyframeMessage() *bus.DeviceUpdateMessage {
+	msg := w.newUpdateMessage()
+	msg.Commands = w.CommandsStr
+	msg.Capabilities = w.Spec.Capabilities
+	w.sentState = make(map[string]interface{}, len(w.State))
+	for k, v := range w.State {
+		msg.State[k] = v
+		w.sentState[k] = v
+	}
+
+	w.deltasSent = 0
+	w.lastKeyframe = time.Now()
+	return msg
+}
+
+// Constructs device update message with the next version.
+func (w *deviceWrapper) newUpdateMessage() *bus.DeviceUpdateMessage {
+	w.version++
+
 	msg := bus.NewDeviceUpdateMessage()
 	msg.DeviceType = w.Ctor.DeviceType
 	msg.DeviceID = w.ID()
-	msg.State = w.State
+	msg.State = make(map[string]interface{})
 	msg.WorkerID = w.Ctor.WorkerID
-	msg.Commands = w.CommandsStr
 	msg.DeviceName = w.Name()
+	msg.Version = w.version
+	msg.Unavailable = w.unavailable
+	w.sentUnavailable = w.unavailable
 	return msg
 }
 
@@ -283,6 +493,34 @@ func (w *deviceWrapper) validateDeviceSpec(ctor *wrapperConstruct) {
 		w.commands[v] = method
 		w.CommandsStr = append(w.CommandsStr, v.String())
 	}
+
+	w.emulateColorCommands()
+}
+
+// Removes capabilities of unsupported properties and commands.
+func (w *deviceWrapper) validateCapabilities() {
+	if nil == w.Spec.Capabilities {
+		return
+	}
+
+	caps := &device.Capabilities{
+		Properties: make(map[enums.Property]*device.Capability),
+		Commands:   make(map[enums.Command]*device.Capability),
+	}
+
+	for k, v := range w.Spec.Capabilities.Properties {
+		if nil != v && enums.SliceContainsProperty(w.Spec.SupportedProperties, k) {
+			caps.Properties[k] = v
+		}
+	}
+
+	for k, v := range w.Spec.Capabilities.Commands {
+		if _, ok := w.commands[k]; ok && nil != v {
+			caps.Commands[k] = v
+		}
+	}
+
+	w.Spec.Capabilities = caps
 }
 
 // Updates internal device state which is stored in wrapper.
@@ -373,27 +611,9 @@ func (w *deviceWrapper) getFieldValueOrNil(valField reflect.Value) interface{} {
 	return val
 }
 
-// Calls for updates.
-func (w *deviceWrapper) periodicUpdates(duration time.Duration) {
-	if !w.isPolling {
-		return
-	}
-
-	ticker := time.NewTicker(duration)
-	for {
-		select {
-		case <-w.stopChan:
-			ticker.Stop()
-			return
-		case <-ticker.C:
-			go w.pullUpdate()
-		}
-	}
-}
-
 // Performs data pull from device provider plugin.
 func (w *deviceWrapper) pullUpdate() {
-	if !w.isPolling {
+	if !w.isPolling || w.isFaulted() {
 		return
 	}
 
@@ -407,38 +627,55 @@ func (w *deviceWrapper) pullUpdate() {
 }
 
 // Performs data pull from hub.
-// Hub could have discovered new devices.
+// Hub could have discovered new devices or removed existing ones.
 func (w *deviceWrapper) pullHubUpdate() {
-	hubState, err := w.Ctor.DeviceInterface.(device.IHub).Update()
+	result, err := w.safeCall(func() (interface{}, error) {
+		return w.Ctor.DeviceInterface.(device.IHub).Update()
+	})
+
+	w.callResult(err)
 	if err != nil {
 		w.logger.Error("Failed to fetch hub updates", err)
+		w.pollFailed()
 		return
 	}
+
+	hubState := result.(*device.HubLoadResult)
 	w.processUpdate(hubState)
 
 	for _, d := range hubState.Devices {
 		w.processDiscovery(d)
 	}
+
+	for _, d := range hubState.Removed {
+		w.processRemoval(d)
+	}
 }
 
 // Performs data pull from device.
 func (w *deviceWrapper) pullDeviceUpdate() {
-	state := w.updateMethod.Call(nil)
-	var err error
-	if 0 == len(state) {
+	result, err := w.safeCall(func() (interface{}, error) {
+		return w.updateMethod.Call(nil), nil
+	})
+
+	state, _ := result.([]reflect.Value)
+
+	if nil == err && 0 == len(state) {
 		err = &ErrNoDataFromPlugin{}
 	}
 
-	if len(state) > 1 && nil != state[1].Interface() {
+	if nil == err && len(state) > 1 && nil != state[1].Interface() {
 		err = state[1].Interface().(error)
 	}
 
-	if len(state) > 1 && nil == state[0].Interface() && nil == state[1].Interface() {
+	if nil == err && len(state) > 1 && nil == state[0].Interface() && nil == state[1].Interface() {
 		err = &ErrNoDataFromPlugin{}
 	}
 
+	w.callResult(err)
 	if err != nil {
 		w.logger.Error("Failed to fetch device updates", err)
+		w.pollFailed()
 	} else {
 		w.processUpdate(state[0].Interface())
 	}
@@ -446,10 +683,15 @@ func (w *deviceWrapper) pullDeviceUpdate() {
 
 // Starts listeners of incoming updates/discovery messages.
 func (w *deviceWrapper) startHubListeners() {
+	keyframe := time.NewTicker(keyframeInterval)
+	defer keyframe.Stop()
+
 	for {
 		select {
 		case <-w.stopChan:
 			return
+		case <-keyframe.C:
+			w.requestUpdate()
 		case discovery, ok := <-w.Ctor.LoadData.DeviceDiscoveredChan:
 			if !ok {
 				return
@@ -457,11 +699,23 @@ func (w *deviceWrapper) startHubListeners() {
 			w.logger.Debug("Received discovery callback for the device")
 
 			w.processDiscovery(discovery)
+		case removed, ok := <-w.Ctor.LoadData.DeviceRemovedChan:
+			if !ok {
+				return
+			}
+			w.logger.Debug("Received removal callback for the device")
+
+			w.processRemoval(removed)
 		case update, ok := <-w.Ctor.LoadData.DeviceStateUpdateChan:
 			if !ok {
 				return
 			}
 			w.processUpdate(update.State)
+		case event, ok := <-w.Ctor.LoadData.DeviceEventChan:
+			if !ok {
+				return
+			}
+			w.processEvent(event)
 		}
 	}
 }
@@ -469,15 +723,25 @@ func (w *deviceWrapper) startHubListeners() {
 // Starts listeners of incoming messages.
 // Only hub listens for discovery.
 func (w *deviceWrapper) startDeviceListeners() {
+	keyframe := time.NewTicker(keyframeInterval)
+	defer keyframe.Stop()
+
 	for {
 		select {
 		case <-w.stopChan:
 			return
+		case <-keyframe.C:
+			w.requestUpdate()
 		case update, ok := <-w.Ctor.LoadData.DeviceStateUpdateChan:
 			if !ok {
 				return
 			}
 			w.processUpdate(update.State)
+		case event, ok := <-w.Ctor.LoadData.DeviceEventChan:
+			if !ok {
+				return
+			}
+			w.processEvent(event)
 		}
 	}
 }
@@ -486,6 +750,78 @@ func (w *deviceWrapper) startDeviceListeners() {
 func (w *deviceWrapper) processUpdate(state interface{}) {
 	w.logger.Debug("Received update for the device")
 	w.setState(state)
+
+	w.updateMutex.Lock()
+	w.failures = 0
+	w.lastData = time.Now()
+	if w.unavailable {
+		w.logger.Info("Device is available again")
+		w.unavailable = false
+	}
+	w.updateMutex.Unlock()
+
+	w.requestUpdate()
+}
+
+// Asks worker to send device update.
+// Keyframe is sent if keyframe interval has passed, so master sees stable devices as alive.
+func (w *deviceWrapper) requestUpdate() {
+	w.Ctor.StatusUpdatesChan <- &UpdateEvent{
+		ID: w.ID(),
+	}
+}
+
+// Processing momentary event from provider plugin.
+// Events are sent as is and don't change device state.
+func (w *deviceWrapper) processEvent(event *device.EventData) {
+	if nil == event || !event.Event.IsADeviceEvent() {
+		w.logger.Warn("Received unknown device event")
+		return
+	}
+
+	w.logger.Debug("Received event for the device", "event", event.Event.String())
+	w.Ctor.StatusUpdatesChan <- &UpdateEvent{
+		ID:    w.ID(),
+		Event: event,
+	}
+}
+
+// Registers failed poll.
+func (w *deviceWrapper) pollFailed() {
+	w.updateMutex.Lock()
+	w.failures++
+	failed := w.failures >= unavailableFailures
+	w.updateMutex.Unlock()
+
+	if failed {
+		w.setUnavailable()
+	}
+}
+
+// Checks whether device sent any data within allowed number of poll periods.
+// This covers plugins which are hanging in Update call.
+func (w *deviceWrapper) checkAvailability() {
+	w.updateMutex.Lock()
+	silent := time.Since(w.lastData) > time.Duration(unavailablePeriods)*w.Spec.UpdatePeriod
+	w.updateMutex.Unlock()
+
+	if silent {
+		w.setUnavailable()
+	}
+}
+
+// Marks device as unavailable and notifies master.
+func (w *deviceWrapper) setUnavailable() {
+	w.updateMutex.Lock()
+	if w.unavailable {
+		w.updateMutex.Unlock()
+		return
+	}
+
+	w.unavailable = true
+	w.updateMutex.Unlock()
+
+	w.logger.Warn("Device is unavailable")
 	w.Ctor.StatusUpdatesChan <- &UpdateEvent{
 		ID: w.ID(),
 	}
@@ -512,7 +848,9 @@ func (w *deviceWrapper) processDiscovery(d *device.DiscoveredDevices) {
 		Logger:                log,
 		Secret:                w.Ctor.Secret,
 		DeviceDiscoveredChan:  w.Ctor.LoadData.DeviceDiscoveredChan,
+		DeviceRemovedChan:     w.Ctor.LoadData.DeviceRemovedChan,
 		DeviceStateUpdateChan: make(chan *device.StateUpdateData, 10),
+		DeviceEventChan:       make(chan *device.EventData, 10),
 	}
 
 	loadedDevice, ok := d.Interface.(device.IDevice)
@@ -543,7 +881,7 @@ func (w *deviceWrapper) processDiscovery(d *device.DiscoveredDevices) {
 		StatusUpdatesChan: w.Ctor.StatusUpdatesChan,
 		UOM:               w.Ctor.UOM,
 		Validator:         w.Ctor.Validator,
-		processor:         newDeviceProcessor(d.Type, w.Ctor.RawConfig),
+		processor:         newDeviceProcessor(d.Type, w.Ctor.RawConfig, subLoadData.DeviceEventChan),
 		RawConfig:         w.Ctor.RawConfig,
 	}
 
@@ -557,5 +895,58 @@ func (w *deviceWrapper) processDiscovery(d *device.DiscoveredDevices) {
 		State: d.State,
 	}
 
-	w.children = append(w.children, wrapper)
+	w.addChild(wrapper)
+}
+
+// Processing removal message from hub provider plugin.
+// Child wrapper is unloaded and worker is notified, so master could forget the device.
+func (w *deviceWrapper) processRemoval(d *device.RemovedDevice) {
+	if nil == d {
+		return
+	}
+
+	id := getDeviceID(w.Ctor.DeviceConfigName, d.Type, d.Name)
+	removed := make([]IDeviceWrapperProvider, 0)
+
+	w.childMutex.Lock()
+	children := make([]IDeviceWrapperProvider, 0, len(w.children))
+	for _, v := range w.children {
+		if v.ID() == id {
+			removed = append(removed, v)
+			continue
+		}
+
+		children = append(children, v)
+	}
+	w.children = children
+	w.childMutex.Unlock()
+
+	if 0 == len(removed) {
+		w.logger.Warn("Hub reported removal of unknown device", common.LogNameToken, d.Name)
+		return
+	}
+
+	w.logger.Info("Device was removed from the hub", common.LogNameToken, d.Name)
+	for _, v := range removed {
+		v.Unload()
+	}
+
+	w.Ctor.StatusUpdatesChan <- &UpdateEvent{
+		ID:      id,
+		Removed: true,
+	}
+}
+
+// Registers device served by the hub.
+func (w *deviceWrapper) addChild(child IDeviceWrapperProvider) {
+	w.childMutex.Lock()
+	defer w.childMutex.Unlock()
+
+	w.children = append(w.children, child)
+}
+
+// Returns normalized device ID.
+func getDeviceID(configName string, deviceType enums.DeviceType, name string) string {
+	return fmt.Sprintf("%s.%s.%s", utils.NormalizeDeviceName(configName),
+		utils.NormalizeDeviceName(deviceType.String()), utils.NormalizeDeviceName(name))
 }
@@ -0,0 +1,454 @@
+package device
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/systems/bus"
+)
+
+// Fake switch plugin.
+type fakeSwitch struct {
+	spec  *device.Spec
+	state *device.SwitchState
+	err   error
+}
+
+func (*fakeSwitch) Init(*device.InitDataDevice) error {
+	return nil
+}
+
+func (*fakeSwitch) Unload() {
+}
+
+func (*fakeSwitch) GetName() string {
+	return "fake switch"
+}
+
+func (f *fakeSwitch) GetSpec() *device.Spec {
+	if nil != f.spec {
+		return f.spec
+	}
+
+	return &device.Spec{
+		SupportedCommands:   []enums.Command{enums.CmdOn, enums.CmdOff},
+		SupportedProperties: []enums.Property{enums.PropOn, enums.PropPower},
+	}
+}
+
+func (f *fakeSwitch) Load() (*device.SwitchState, error) {
+	return f.state, nil
+}
+
+func (*fakeSwitch) On() error {
+	return nil
+}
+
+func (*fakeSwitch) Off() error {
+	return nil
+}
+
+func (*fakeSwitch) Toggle() error {
+	return nil
+}
+
+func (f *fakeSwitch) Update() (*device.SwitchState, error) {
+	return f.state, f.err
+}
+
+// Loads fake switch wrapper.
+func getFakeSwitchWrapper(t *testing.T, s device.ISwitch) *deviceWrapper {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddLoader(s)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevSwitch,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+
+	require.NoError(t, err, "load")
+	require.Equal(t, 1, len(wrappers), "wrappers")
+	return wrappers[0].(*deviceWrapper)
+}
+
+// Tests that only changed properties are sent after a keyframe.
+func TestDeltaUpdates(t *testing.T) {
+	s := &fakeSwitch{state: &device.SwitchState{On: true, Power: 10}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	msg := w.GetUpdateMessage()
+	assert.False(t, msg.IsDelta, "first message")
+	assert.Equal(t, 2, len(msg.State), "first state")
+	assert.Equal(t, 2, len(msg.Commands), "first commands")
+	assert.Equal(t, uint64(1), msg.Version, "first version")
+
+	w.setState(&device.SwitchState{On: true, Power: 20})
+	msg = w.GetUpdateMessage()
+	assert.True(t, msg.IsDelta, "delta")
+	assert.Equal(t, map[string]interface{}{"power": float64(20)}, msg.State, "delta state")
+	assert.Nil(t, msg.Commands, "delta commands")
+	assert.Equal(t, uint64(2), msg.Version, "delta version")
+
+	assert.Nil(t, w.GetUpdateMessage(), "empty delta")
+
+	msg = w.GetKeyframeMessage()
+	assert.False(t, msg.IsDelta, "keyframe")
+	assert.Equal(t, 2, len(msg.State), "keyframe state")
+	assert.Equal(t, uint64(3), msg.Version, "keyframe version")
+
+	w.setUnavailable()
+	msg = w.GetUpdateMessage()
+	require.NotNil(t, msg, "unavailable")
+	assert.True(t, msg.Unavailable, "unavailable delta")
+	assert.Equal(t, 0, len(msg.State), "unavailable state")
+
+	w.updateMutex.Lock()
+	delete(w.State, "power")
+	w.updateMutex.Unlock()
+	msg = w.GetUpdateMessage()
+	require.NotNil(t, msg, "removed property")
+	assert.False(t, msg.IsDelta, "removed property keyframe")
+	assert.Equal(t, map[string]interface{}{"on": true}, msg.State, "removed property state")
+}
+
+// Tests that keyframe is sent after configured number of deltas.
+func TestDeltaKeyframe(t *testing.T) {
+	prev := keyframeUpdates
+	keyframeUpdates = 2
+	defer func() {
+		keyframeUpdates = prev
+	}()
+
+	s := &fakeSwitch{state: &device.SwitchState{On: true}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	expected := []bool{false, true, true, false, true}
+	for i, v := range expected {
+		w.setState(&device.SwitchState{On: 0 == i%2})
+		assert.Equal(t, v, w.GetUpdateMessage().IsDelta, "message %d", i)
+	}
+}
+
+// Fake thermostat plugin.
+type fakeThermostat struct {
+	mode string
+}
+
+func (*fakeThermostat) Init(*device.InitDataDevice) error {
+	return nil
+}
+
+func (*fakeThermostat) Unload() {
+}
+
+func (*fakeThermostat) GetName() string {
+	return "fake thermostat"
+}
+
+func (*fakeThermostat) GetSpec() *device.Spec {
+	return &device.Spec{
+		SupportedCommands: []enums.Command{enums.CmdSetTemperature, enums.CmdSetMode, enums.CmdSetFanMode},
+		SupportedProperties: []enums.Property{enums.PropTemperature, enums.PropTargetTemperature,
+			enums.PropHvacMode, enums.PropHvacAction},
+	}
+}
+
+func (*fakeThermostat) Load() (*device.ThermostatState, error) {
+	return &device.ThermostatState{Temperature: 20, TargetTemperature: 22, HvacMode: enums.HvacModeHeat,
+		HvacAction: enums.HvacActionHeating}, nil
+}
+
+func (f *fakeThermostat) Update() (*device.ThermostatState, error) {
+	return f.Load()
+}
+
+func (*fakeThermostat) SetTemperature(common.Float) error {
+	return nil
+}
+
+func (f *fakeThermostat) SetMode(mode common.String) error {
+	f.mode = mode.Value
+	return nil
+}
+
+func (*fakeThermostat) SetFanMode(common.String) error {
+	return nil
+}
+
+// Tests thermostat device loading.
+func TestThermostat(t *testing.T) {
+	s := &fakeThermostat{}
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddLoader(s)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevThermostat,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	require.Equal(t, 1, len(wrappers), "wrappers")
+	defer wrappers[0].Unload()
+
+	msg := wrappers[0].GetUpdateMessage()
+	assert.Equal(t, enums.DevThermostat, msg.DeviceType, "type")
+	assert.Equal(t, 3, len(msg.Commands), "commands")
+	assert.Equal(t, enums.HvacModeHeat, msg.State["hvac_mode"], "mode")
+	assert.Equal(t, enums.HvacActionHeating, msg.State["hvac_action"], "action")
+	assert.Equal(t, float64(22), msg.State["target_temperature"], "target temperature")
+
+	wrappers[0].InvokeCommand(enums.CmdSetMode, map[string]interface{}{"value": "cool"})
+	assert.Equal(t, "cool", s.mode, "set mode")
+
+	wrappers[0].InvokeCommand(enums.CmdSetMode, map[string]interface{}{"value": "freeze"})
+	assert.Equal(t, "cool", s.mode, "unknown mode")
+}
+
+// Fake media player plugin.
+type fakeMediaPlayer struct {
+	volume uint8
+	source string
+	muted  bool
+}
+
+func (*fakeMediaPlayer) Init(*device.InitDataDevice) error {
+	return nil
+}
+
+func (*fakeMediaPlayer) Unload() {
+}
+
+func (*fakeMediaPlayer) GetName() string {
+	return "fake player"
+}
+
+func (*fakeMediaPlayer) GetSpec() *device.Spec {
+	return &device.Spec{
+		SupportedCommands: []enums.Command{enums.CmdPlay, enums.CmdPause, enums.CmdSetVolume, enums.CmdMute,
+			enums.CmdSelectSource, enums.CmdSetBrightness},
+		SupportedProperties: []enums.Property{enums.PropPlayerStatus, enums.PropVolume, enums.PropMuted,
+			enums.PropSource, enums.PropTitle},
+	}
+}
+
+func (f *fakeMediaPlayer) Load() (*device.MediaPlayerState, error) {
+	return &device.MediaPlayerState{PlayerStatus: enums.PlayerPlaying, Volume: f.volume, Muted: f.muted,
+		Source: f.source, Title: "song"}, nil
+}
+
+func (f *fakeMediaPlayer) Update() (*device.MediaPlayerState, error) {
+	return f.Load()
+}
+
+func (*fakeMediaPlayer) Play() error {
+	return nil
+}
+
+func (*fakeMediaPlayer) Pause() error {
+	return nil
+}
+
+func (*fakeMediaPlayer) Next() error {
+	return nil
+}
+
+func (*fakeMediaPlayer) Previous() error {
+	return nil
+}
+
+func (f *fakeMediaPlayer) SetVolume(volume common.Position) error {
+	f.volume = *volume.Value
+	return nil
+}
+
+func (f *fakeMediaPlayer) Mute() error {
+	f.muted = !f.muted
+	return nil
+}
+
+func (f *fakeMediaPlayer) SelectSource(source common.String) error {
+	f.source = source.Value
+	return nil
+}
+
+// Loads fake media player wrapper.
+func getFakeMediaPlayerWrapper(t *testing.T, s *fakeMediaPlayer) *deviceWrapper {
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddLoader(s)
+
+	wrappers, err := LoadDevice(&ConstructDevice{
+		DeviceName:        "fake",
+		DeviceType:        enums.DevMediaPlayer,
+		ConfigName:        "test",
+		Settings:          settings,
+		StatusUpdatesChan: make(chan *UpdateEvent, 10),
+		DiscoveryChan:     make(chan *NewDeviceDiscoveredEvent, 10),
+	})
+	require.NoError(t, err, "load")
+	require.Equal(t, 1, len(wrappers), "wrappers")
+	return wrappers[0].(*deviceWrapper)
+}
+
+// Tests media player commands mapping.
+func TestMediaPlayer(t *testing.T) {
+	s := &fakeMediaPlayer{volume: 10, source: "tv"}
+	w := getFakeMediaPlayerWrapper(t, s)
+	defer w.Unload()
+
+	assert.Equal(t, []string{"play", "pause", "set-volume", "mute", "select-source"}, w.CommandsStr, "commands")
+	assert.Equal(t, enums.PlayerPlaying, w.State["player_status"], "status")
+	assert.Equal(t, "song", w.State["title"], "title")
+
+	w.InvokeCommand(enums.CmdSetVolume, map[string]interface{}{"value": 30})
+	w.InvokeCommand(enums.CmdSelectSource, map[string]interface{}{"value": "radio"})
+	w.InvokeCommand(enums.CmdMute, nil)
+
+	assert.Equal(t, uint8(30), s.volume, "volume")
+	assert.Equal(t, "radio", s.source, "source")
+	assert.True(t, s.muted, "muted")
+
+	w.InvokeCommand(enums.CmdSetVolume, map[string]interface{}{"value": 0})
+	assert.Equal(t, uint8(0), s.volume, "zero volume")
+}
+
+// Tests device availability tracking.
+func TestAvailability(t *testing.T) {
+	s := &fakeSwitch{
+		state: &device.SwitchState{On: true},
+		spec: &device.Spec{
+			SupportedCommands:   []enums.Command{enums.CmdOn},
+			SupportedProperties: []enums.Property{enums.PropOn},
+			UpdatePeriod:        time.Hour,
+		},
+	}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	assert.False(t, w.GetUpdateMessage().Unavailable, "initial")
+
+	s.err = errors.New("failed")
+	for ii := 1; ii < unavailableFailures; ii++ {
+		w.pullDeviceUpdate()
+	}
+	assert.Nil(t, w.GetUpdateMessage(), "before threshold")
+
+	w.pullDeviceUpdate()
+	assert.True(t, w.GetUpdateMessage().Unavailable, "after threshold")
+
+	s.err = nil
+	w.pullDeviceUpdate()
+	assert.False(t, w.GetUpdateMessage().Unavailable, "recovered")
+
+	w.updateMutex.Lock()
+	w.lastData = time.Now().Add(-time.Duration(unavailablePeriods+1) * time.Hour)
+	w.updateMutex.Unlock()
+	w.checkAvailability()
+	assert.True(t, w.GetUpdateMessage().Unavailable, "no data")
+}
+
+// Tests that capabilities are sent with keyframes.
+func TestCapabilities(t *testing.T) {
+	s := &fakeSwitch{
+		state: &device.SwitchState{On: true},
+		spec: &device.Spec{
+			SupportedCommands:   []enums.Command{enums.CmdOn},
+			SupportedProperties: []enums.Property{enums.PropOn, enums.PropPower},
+			Capabilities: &device.Capabilities{
+				Properties: map[enums.Property]*device.Capability{
+					enums.PropPower:      {ReadOnly: true, Unit: "W"},
+					enums.PropBrightness: {Max: 100},
+				},
+				Commands: map[enums.Command]*device.Capability{
+					enums.CmdOn:            {},
+					enums.CmdSetBrightness: {Max: 100},
+				},
+			},
+		},
+	}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	msg := w.GetKeyframeMessage()
+	require.NotNil(t, msg.Capabilities, "keyframe")
+	assert.Equal(t, 1, len(msg.Capabilities.Properties), "properties")
+	assert.Equal(t, 1, len(msg.Capabilities.Commands), "commands")
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err, "marshal")
+	received := &bus.DeviceUpdateMessage{}
+	require.NoError(t, json.Unmarshal(data, received), "unmarshal")
+	assert.Equal(t, "W", received.Capabilities.Properties[enums.PropPower].Unit, "unit")
+
+	w.setState(&device.SwitchState{On: false})
+	assert.Nil(t, w.GetUpdateMessage().Capabilities, "delta")
+}
+
+// Tests that momentary events are forwarded without changing the state.
+func TestDeviceEvents(t *testing.T) {
+	s := &fakeSwitch{state: &device.SwitchState{On: true}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	w.GetUpdateMessage()
+	w.Ctor.LoadData.DeviceEventChan <- &device.EventData{Event: enums.DeviceEvent(-1)}
+	w.Ctor.LoadData.DeviceEventChan <- &device.EventData{
+		Event: enums.EvtDoubleClick,
+		Data:  map[string]interface{}{"button": 1},
+	}
+
+	select {
+	case e := <-w.Ctor.StatusUpdatesChan:
+		require.NotNil(t, e.Event, "event")
+		assert.Equal(t, w.ID(), e.ID, "id")
+		assert.Equal(t, enums.EvtDoubleClick, e.Event.Event, "type")
+		assert.Equal(t, 1, e.Event.Data["button"], "data")
+	case <-time.After(1 * time.Second):
+		assert.Fail(t, "event was not sent")
+	}
+
+	assert.Nil(t, w.GetUpdateMessage(), "state changed")
+}
+
+// Tests that keyframe is sent periodically even if state didn't change.
+func TestKeyframeInterval(t *testing.T) {
+	prev := keyframeInterval
+	keyframeInterval = 100 * time.Millisecond
+	defer func() {
+		keyframeInterval = prev
+	}()
+
+	s := &fakeSwitch{state: &device.SwitchState{On: true}}
+	w := getFakeSwitchWrapper(t, s)
+	defer w.Unload()
+
+	require.NotNil(t, w.GetUpdateMessage(), "first message")
+	require.Nil(t, w.GetUpdateMessage(), "no changes")
+
+	select {
+	case <-w.Ctor.StatusUpdatesChan:
+	case <-time.After(time.Second):
+		require.Fail(t, "update was not requested")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	msg := w.GetUpdateMessage()
+	require.NotNil(t, msg, "keyframe")
+	assert.False(t, msg.IsDelta, "delta")
+}
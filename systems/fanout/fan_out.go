@@ -12,26 +12,40 @@ import (
 
 // Implements IInternalFanOutProvider.
 type provider struct {
-	device  sync.Mutex
-	trigger sync.Mutex
+	device   sync.Mutex
+	event    sync.Mutex
+	trigger  sync.Mutex
+	metadata sync.Mutex
 
 	inDeviceUpdates  chan *common.MsgDeviceUpdate
 	outDeviceUpdates map[int64]chan *common.MsgDeviceUpdate
 
+	inDeviceEvents  chan *common.MsgDeviceEvent
+	outDeviceEvents map[int64]chan *common.MsgDeviceEvent
+
 	inTriggerUpdates  chan string
 	outTriggerUpdates map[int64]chan string
+
+	inMetadataUpdates  chan string
+	outMetadataUpdates map[int64]chan string
 }
 
 // NewFanOut constructs new FanOut provider.
 func NewFanOut() providers.IInternalFanOutProvider {
 	p := &provider{
-		inTriggerUpdates:  make(chan string, 10),
-		outTriggerUpdates: make(map[int64]chan string),
-		inDeviceUpdates:   make(chan *common.MsgDeviceUpdate, 10),
-		outDeviceUpdates:  make(map[int64]chan *common.MsgDeviceUpdate),
-
-		device:  sync.Mutex{},
-		trigger: sync.Mutex{},
+		inTriggerUpdates:   make(chan string, 10),
+		outTriggerUpdates:  make(map[int64]chan string),
+		inDeviceUpdates:    make(chan *common.MsgDeviceUpdate, 10),
+		outDeviceUpdates:   make(map[int64]chan *common.MsgDeviceUpdate),
+		inDeviceEvents:     make(chan *common.MsgDeviceEvent, 10),
+		outDeviceEvents:    make(map[int64]chan *common.MsgDeviceEvent),
+		inMetadataUpdates:  make(chan string, 10),
+		outMetadataUpdates: make(map[int64]chan string),
+
+		device:   sync.Mutex{},
+		event:    sync.Mutex{},
+		trigger:  sync.Mutex{},
+		metadata: sync.Mutex{},
 	}
 
 	go p.internalCycle()
@@ -69,6 +83,37 @@ func (p *provider) ChannelInDeviceUpdates() chan *common.MsgDeviceUpdate {
 	return p.inDeviceUpdates
 }
 
+// SubscribeDeviceEvents allows to subscribe to the devices events.
+func (p *provider) SubscribeDeviceEvents() (int64, chan *common.MsgDeviceEvent) {
+	p.event.Lock()
+	defer p.event.Unlock()
+
+	c := make(chan *common.MsgDeviceEvent, 10)
+	rnd := p.getID()
+	p.outDeviceEvents[rnd] = c
+	return rnd, c
+}
+
+// UnSubscribeDeviceEvents allows to un-subscribe from the device events.
+// nolint:dupl
+func (p *provider) UnSubscribeDeviceEvents(id int64) {
+	p.event.Lock()
+	defer p.event.Unlock()
+
+	c, ok := p.outDeviceEvents[id]
+	if !ok {
+		return
+	}
+
+	close(c)
+	delete(p.outDeviceEvents, id)
+}
+
+// ChannelInDeviceEvents returns input channel for the device events.
+func (p *provider) ChannelInDeviceEvents() chan *common.MsgDeviceEvent {
+	return p.inDeviceEvents
+}
+
 // SubscribeTriggerUpdates allows to subscribe for the triggers updates.
 func (p *provider) SubscribeTriggerUpdates() (int64, chan string) {
 	p.trigger.Lock()
@@ -100,6 +145,38 @@ func (p *provider) ChannelInTriggerUpdates() chan string {
 	return p.inTriggerUpdates
 }
 
+// SubscribeMetadataUpdates allows to subscribe for the devices metadata updates.
+// Channel receives IDs of the devices with changed metadata.
+func (p *provider) SubscribeMetadataUpdates() (int64, chan string) {
+	p.metadata.Lock()
+	defer p.metadata.Unlock()
+
+	c := make(chan string, 10)
+	rnd := p.getID()
+	p.outMetadataUpdates[rnd] = c
+	return rnd, c
+}
+
+// UnSubscribeMetadataUpdates allows to un-subscribe from the devices metadata updates.
+// nolint:dupl
+func (p *provider) UnSubscribeMetadataUpdates(id int64) {
+	p.metadata.Lock()
+	defer p.metadata.Unlock()
+
+	c, ok := p.outMetadataUpdates[id]
+	if !ok {
+		return
+	}
+
+	close(c)
+	delete(p.outMetadataUpdates, id)
+}
+
+// ChannelInMetadataUpdates returns input channel for the devices metadata updates.
+func (p *provider) ChannelInMetadataUpdates() chan string {
+	return p.inMetadataUpdates
+}
+
 // Returns random ID.
 func (p *provider) getID() int64 {
 	return utils.TimeNow() + rand.Int63()
@@ -110,8 +187,12 @@ func (p *provider) internalCycle() {
 		select {
 		case u := <-p.inDeviceUpdates:
 			go p.deviceUpdates(u)
+		case e := <-p.inDeviceEvents:
+			go p.deviceEvents(e)
 		case u := <-p.inTriggerUpdates:
 			go p.triggerUpdates(u)
+		case u := <-p.inMetadataUpdates:
+			go p.metadataUpdates(u)
 		}
 	}
 }
@@ -126,6 +207,16 @@ func (p *provider) deviceUpdates(update *common.MsgDeviceUpdate) {
 	}
 }
 
+// Broadcasts device events.
+func (p *provider) deviceEvents(event *common.MsgDeviceEvent) {
+	p.event.Lock()
+	defer p.event.Unlock()
+
+	for _, v := range p.outDeviceEvents {
+		v <- event
+	}
+}
+
 // Broadcasts trigger updates.
 func (p *provider) triggerUpdates(update string) {
 	p.trigger.Lock()
@@ -135,3 +226,13 @@ func (p *provider) triggerUpdates(update string) {
 		v <- update
 	}
 }
+
+// Broadcasts devices metadata updates.
+func (p *provider) metadataUpdates(deviceID string) {
+	p.metadata.Lock()
+	defer p.metadata.Unlock()
+
+	for _, v := range p.outMetadataUpdates {
+		v <- deviceID
+	}
+}
@@ -121,3 +121,46 @@ func TestTriggerUpdates(t *testing.T) {
 	assert.True(t, d2Exited, "exit channel 2")
 
 }
+
+// Tests devices events channels.
+func TestDeviceEvents(t *testing.T) {
+	fo := NewFanOut()
+	id1, e1 := fo.SubscribeDeviceEvents()
+	_, e2 := fo.SubscribeDeviceEvents()
+
+	fo.ChannelInDeviceEvents() <- &common.MsgDeviceEvent{ID: "test"}
+	for _, v := range []chan *common.MsgDeviceEvent{e1, e2} {
+		select {
+		case m := <-v:
+			assert.Equal(t, "test", m.ID, "event")
+		case <-time.After(1 * time.Second):
+			assert.Fail(t, "event was not delivered")
+		}
+	}
+
+	fo.UnSubscribeDeviceEvents(id1)
+	_, ok := <-e1
+	assert.False(t, ok, "unsubscribe")
+	fo.UnSubscribeDeviceEvents(id1)
+}
+
+// Tests devices metadata updates channels.
+func TestMetadataUpdates(t *testing.T) {
+	fo := NewFanOut()
+	id1, m1 := fo.SubscribeMetadataUpdates()
+	_, m2 := fo.SubscribeMetadataUpdates()
+
+	fo.ChannelInMetadataUpdates() <- "test"
+	for _, v := range []chan string{m1, m2} {
+		select {
+		case m := <-v:
+			assert.Equal(t, "test", m, "metadata")
+		case <-time.After(1 * time.Second):
+			assert.Fail(t, "metadata update was not delivered")
+		}
+	}
+
+	fo.UnSubscribeMetadataUpdates(id1)
+	_, ok := <-m1
+	assert.False(t, ok, "channel is not closed")
+}
@@ -23,10 +23,12 @@ type provider struct {
 
 	internalID string
 
-	devicesExp  []glob.Glob
-	updatesChan chan *common.MsgDeviceUpdate
-	logger      common.ILoggerProvider
+	devicesExp   []glob.Glob
+	updatesChan  chan *common.MsgDeviceUpdate
+	metadataChan chan string
+	logger       common.ILoggerProvider
 	server      providers.IServerProvider
+	metadata    providers.IMetadataProvider
 
 	devices   []*groupDevice
 	unmatched []string
@@ -88,6 +90,7 @@ func NewGroupProvider(ctor *ConstructGroup) (providers.IGroupProvider, error) {
 		Commands:   make([]string, 0),
 		State:      make(map[string]interface{}),
 		server:     ctor.Server,
+		metadata:   ctor.Settings.Metadata(),
 	}
 
 	for _, v := range settings.Devices {
@@ -102,7 +105,9 @@ func NewGroupProvider(ctor *ConstructGroup) (providers.IGroupProvider, error) {
 
 	fanOut := ctor.Settings.FanOut()
 	_, provider.updatesChan = fanOut.SubscribeDeviceUpdates()
+	_, provider.metadataChan = fanOut.SubscribeMetadataUpdates()
 	go provider.deviceUpdates()
+	go provider.metadataUpdates()
 
 	return provider, nil
 }
@@ -151,6 +156,11 @@ func (p *provider) processDeviceUpdates(msg *common.MsgDeviceUpdate) {
 		return
 	}
 
+	if msg.Removed {
+		p.removeDevice(msg.ID)
+		return
+	}
+
 	if helpers.SliceContainsString(p.unmatched, msg.ID) {
 		return
 	}
@@ -171,32 +181,107 @@ func (p *provider) processDeviceUpdates(msg *common.MsgDeviceUpdate) {
 	}
 
 	if !found {
-		matched := false
-		for _, v := range p.devicesExp {
-			if v.Match(msg.ID) {
-				matched = true
-				break
-			}
+		if !p.isMatched(msg.ID) {
+			p.unmatched = append(p.unmatched, msg.ID)
+			return
 		}
 
-		if !matched {
-			p.unmatched = append(p.unmatched, msg.ID)
+		if !p.addDevice(msg.ID, kd.Commands, msg.State) {
 			return
 		}
+	}
 
-		exp, err := glob.Compile(msg.ID)
-		if err != nil {
+	p.pushUpdate()
+}
+
+// Subscribes for devices metadata updates.
+func (p *provider) metadataUpdates() {
+	for deviceID := range p.metadataChan {
+		go p.processMetadataUpdates(deviceID)
+	}
+}
+
+// Re-evaluates device membership, since aliases or tags might have changed.
+func (p *provider) processMetadataUpdates(deviceID string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if deviceID == p.internalID {
+		return
+	}
+
+	p.unmatched = helpers.SliceRemoveString(p.unmatched, deviceID)
+	if !p.isMatched(deviceID) {
+		p.removeDevice(deviceID)
+		return
+	}
+
+	for _, v := range p.devices {
+		if v.ID == deviceID {
 			return
 		}
+	}
 
-		p.devices = append(p.devices, &groupDevice{
-			ID:       msg.ID,
-			Commands: kd.Commands,
-			State:    msg.State,
-			IDExp:    exp,
-		})
+	kd := p.server.GetDevice(deviceID)
+	state := p.server.GetDeviceState(deviceID)
+	if nil == kd || nil == state || kd.Type == enums.DevGroup {
+		return
 	}
 
+	if p.addDevice(deviceID, kd.Commands, state) {
+		p.pushUpdate()
+	}
+}
+
+// Checks whether device matches any of the group expressions.
+func (p *provider) isMatched(deviceID string) bool {
+	for _, v := range p.devicesExp {
+		if p.metadata.Match(v, deviceID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adds device to the group.
+func (p *provider) addDevice(deviceID string, commands []string, state map[enums.Property]interface{}) bool {
+	exp, err := glob.Compile(deviceID)
+	if err != nil {
+		return false
+	}
+
+	p.devices = append(p.devices, &groupDevice{
+		ID:       deviceID,
+		Commands: commands,
+		State:    state,
+		IDExp:    exp,
+	})
+
+	return true
+}
+
+// Removes device from the group.
+func (p *provider) removeDevice(deviceID string) {
+	p.unmatched = helpers.SliceRemoveString(p.unmatched, deviceID)
+
+	devices := make([]*groupDevice, 0, len(p.devices))
+	for _, v := range p.devices {
+		if v.ID != deviceID {
+			devices = append(devices, v)
+		}
+	}
+
+	if len(devices) == len(p.devices) {
+		return
+	}
+
+	p.devices = devices
+	p.pushUpdate()
+}
+
+// Re-calculates group state and sends it to the master.
+func (p *provider) pushUpdate() {
 	p.updateGroupState()
 	p.updateGroupCommands()
 
@@ -211,11 +296,11 @@ func (p *provider) processDeviceUpdates(msg *common.MsgDeviceUpdate) {
 
 // Updates group state.
 func (p *provider) updateGroupState() {
+	p.State = make(map[string]interface{})
 	if 0 == len(p.devices) {
 		return
 	}
 
-	p.State = make(map[string]interface{})
 	for k, s := range p.devices[0].State {
 		found := true
 
@@ -238,6 +323,9 @@ func (p *provider) updateGroupState() {
 // Updates available commands.
 func (p *provider) updateGroupCommands() {
 	p.Commands = make([]string, 0)
+	if 0 == len(p.devices) {
+		return
+	}
 
 	for _, c := range p.devices[0].Commands {
 		found := true
@@ -16,6 +16,7 @@ type grSuite struct {
 	suite.Suite
 
 	invoked int
+	s       providers.ISettingsProvider
 	f       providers.IInternalFanOutProvider
 	prov    providers.IGroupProvider
 	srv     mocks.IFakeServer
@@ -32,6 +33,7 @@ devices:
   - otherdevice
 `
 	s := mocks.FakeNewSettings(nil, false, nil, nil)
+	g.s = s
 	g.f = s.FanOut()
 	g.invoked = 0
 	g.srv = mocks.FakeNewServer(func() {
@@ -104,6 +106,43 @@ func (g *grSuite) TestNoUpdatesWrongDevice() {
 	assert.Equal(g.T(), 0, g.invoked, "invokes mismatch")
 }
 
+// Tests device removal.
+func (g *grSuite) TestRemove() {
+	g.f.ChannelInDeviceUpdates() <- g.getMsg()
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 1, len(g.prov.Devices()), "not added")
+
+	g.f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:      "device1",
+		Removed: true,
+	}
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 0, len(g.prov.Devices()), "not removed")
+
+	g.prov.InvokeCommand(enums.CmdOn, nil)
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 0, g.invoked, "invokes mismatch")
+}
+
+// Tests membership changes with device metadata.
+func (g *grSuite) TestMetadataUpdate() {
+	g.f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "light1",
+	}
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 0, len(g.prov.Devices()), "unmatched device was added")
+
+	g.s.Metadata().Set("light1", &providers.DeviceMetadata{Aliases: []string{"device_light"}}) // nolint: gosec
+	g.f.ChannelInMetadataUpdates() <- "light1"
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), []string{"light1"}, g.prov.Devices(), "alias was not applied")
+
+	g.s.Metadata().Delete("light1") // nolint: gosec
+	g.f.ChannelInMetadataUpdates() <- "light1"
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 0, len(g.prov.Devices()), "alias was not removed")
+}
+
 // Tests correct name.
 func (g *grSuite) TestName() {
 	assert.Equal(g.T(), "group.cabinet_lights", g.prov.ID())
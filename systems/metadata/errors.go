@@ -0,0 +1,24 @@
+package metadata
+
+import "fmt"
+
+// ErrWrongRename defines incorrect device rename request.
+type ErrWrongRename struct {
+	From string
+	To   string
+}
+
+// Error formats output.
+func (e *ErrWrongRename) Error() string {
+	return fmt.Sprintf("can't rename device %s to %s", e.From, e.To)
+}
+
+// ErrUnknownDevice defines device which was never registered.
+type ErrUnknownDevice struct {
+	ID string
+}
+
+// Error formats output.
+func (e *ErrUnknownDevice) Error() string {
+	return fmt.Sprintf("device %s was never registered", e.ID)
+}
@@ -0,0 +1,346 @@
+// Package metadata contains user-defined devices metadata store.
+package metadata
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/helpers"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Logger system representation.
+	logSystem = "metadata"
+	// Prefix used for selecting devices by tag.
+	tagPrefix = "tag:"
+)
+
+// Metadata provider.
+type provider struct {
+	sync.Mutex
+
+	location string
+	logger   common.ILoggerProvider
+	fanOut   providers.IInternalFanOutProvider
+	devices  map[string]*providers.DeviceMetadata
+}
+
+// ConstructMetadata has data required for a new metadata provider.
+type ConstructMetadata struct {
+	Logger   common.ILoggerProvider
+	FanOut   providers.IInternalFanOutProvider
+	Location string
+}
+
+// NewEmptyMetadataProvider returns in-memory metadata provider.
+// It is used on workers, since metadata lives on master.
+func NewEmptyMetadataProvider() providers.IMetadataProvider {
+	return &provider{devices: make(map[string]*providers.DeviceMetadata)}
+}
+
+// NewMetadataProvider returns a new metadata provider, persisted in the file.
+func NewMetadataProvider(ctor *ConstructMetadata) providers.IMetadataProvider {
+	p := &provider{
+		location: ctor.Location,
+		logger:   ctor.Logger,
+		fanOut:   ctor.FanOut,
+		devices:  make(map[string]*providers.DeviceMetadata),
+	}
+
+	fileData, err := ioutil.ReadFile(p.location)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			p.logger.Error("Failed to read metadata file", err, common.LogSystemToken, logSystem)
+		}
+
+		return p
+	}
+
+	err = yaml.Unmarshal(fileData, &p.devices)
+	if err != nil {
+		p.logger.Error("Failed to unmarshal metadata file. Be aware that it will be rewritten", err,
+			common.LogSystemToken, logSystem)
+		p.devices = make(map[string]*providers.DeviceMetadata)
+	}
+
+	for k, v := range p.devices {
+		if nil == v {
+			delete(p.devices, k)
+		}
+	}
+
+	return p
+}
+
+// Get returns device metadata or nil.
+func (p *provider) Get(deviceID string) *providers.DeviceMetadata {
+	p.Lock()
+	defer p.Unlock()
+
+	m, ok := p.devices[deviceID]
+	if !ok {
+		return nil
+	}
+
+	return copyMetadata(m)
+}
+
+// All returns metadata of all devices.
+func (p *provider) All() map[string]*providers.DeviceMetadata {
+	p.Lock()
+	defer p.Unlock()
+
+	all := make(map[string]*providers.DeviceMetadata, len(p.devices))
+	for k, v := range p.devices {
+		all[k] = copyMetadata(v)
+	}
+
+	return all
+}
+
+// Set stores user-defined device metadata.
+// System-maintained fields are preserved, empty metadata removes user-defined data.
+// Subscribers are notified, so groups, locations and templates can re-evaluate the device.
+func (p *provider) Set(deviceID string, m *providers.DeviceMetadata) error {
+	if nil == m {
+		m = &providers.DeviceMetadata{}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	updated := copyMetadata(m)
+	updated.FirstSeen = 0
+	updated.PreviousIDs = nil
+	if existing, ok := p.devices[deviceID]; ok {
+		updated.FirstSeen = existing.FirstSeen
+		updated.PreviousIDs = existing.PreviousIDs
+	} else if isEmpty(updated) {
+		return nil
+	}
+
+	devices := p.copyDevices()
+	if isEmpty(updated) && 0 == updated.FirstSeen && 0 == len(updated.PreviousIDs) {
+		delete(devices, deviceID)
+	} else {
+		devices[deviceID] = updated
+	}
+
+	err := p.save(devices)
+	if err == nil {
+		p.notify(deviceID)
+	}
+
+	return err
+}
+
+// Delete removes user-defined device metadata.
+func (p *provider) Delete(deviceID string) error {
+	return p.Set(deviceID, nil)
+}
+
+// Register records the first occurrence of the device ID.
+// Returns true if device was never seen before.
+func (p *provider) Register(deviceID string) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	existing, ok := p.devices[deviceID]
+	if ok && existing.FirstSeen > 0 {
+		return false
+	}
+
+	updated := &providers.DeviceMetadata{}
+	if ok {
+		updated = copyMetadata(existing)
+	}
+	updated.FirstSeen = utils.TimeNow()
+
+	devices := p.copyDevices()
+	devices[deviceID] = updated
+	err := p.save(devices)
+	if err != nil {
+		p.devices[deviceID] = updated
+	}
+
+	return true
+}
+
+// Rename moves device metadata to a new ID.
+// Only registered devices can be renamed.
+// Old ID is kept in the list of previous IDs, so existing selectors still match the device.
+func (p *provider) Rename(from string, to string) error {
+	if "" == from || "" == to || from == to {
+		return &ErrWrongRename{From: from, To: to}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	old, ok := p.devices[from]
+	if !ok || 0 == old.FirstSeen {
+		return &ErrUnknownDevice{ID: from}
+	}
+
+	updated := &providers.DeviceMetadata{}
+	if existing, ok := p.devices[to]; ok {
+		updated = copyMetadata(existing)
+	}
+
+	if isEmpty(updated) {
+		updated.Name = old.Name
+		updated.Icon = old.Icon
+		updated.Hidden = old.Hidden
+		updated.Aliases = old.Aliases
+		updated.Tags = old.Tags
+	}
+
+	if old.FirstSeen > 0 && (0 == updated.FirstSeen || old.FirstSeen < updated.FirstSeen) {
+		updated.FirstSeen = old.FirstSeen
+	}
+
+	previous := make([]string, 0)
+	for _, v := range append(append(updated.PreviousIDs, from), old.PreviousIDs...) {
+		if v != to {
+			previous = append(previous, v)
+		}
+	}
+	updated.PreviousIDs = uniqueStrings(previous)
+
+	devices := p.copyDevices()
+	delete(devices, from)
+	devices[to] = updated
+
+	err := p.save(devices)
+	if err == nil {
+		p.notify(from, to)
+	}
+
+	return err
+}
+
+// Match checks whether device ID, one of device aliases, tags or previous IDs matches the expression.
+// Previous IDs registered again by another device are skipped.
+// Tags are matched with "tag:" prefix.
+func (p *provider) Match(exp glob.Glob, deviceID string) bool {
+	if exp.Match(deviceID) {
+		return true
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	m, ok := p.devices[deviceID]
+	if !ok {
+		return false
+	}
+
+	for _, v := range m.Aliases {
+		if exp.Match(v) {
+			return true
+		}
+	}
+
+	for _, v := range m.Tags {
+		if exp.Match(tagPrefix + v) {
+			return true
+		}
+	}
+
+	for _, v := range m.PreviousIDs {
+		if _, ok := p.devices[v]; ok {
+			continue
+		}
+
+		if exp.Match(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Notifies subscribers about changed devices metadata.
+func (p *provider) notify(deviceIDs ...string) {
+	if nil == p.fanOut {
+		return
+	}
+
+	for _, v := range deviceIDs {
+		p.fanOut.ChannelInMetadataUpdates() <- v
+	}
+}
+
+// Returns a shallow copy of known devices.
+func (p *provider) copyDevices() map[string]*providers.DeviceMetadata {
+	devices := make(map[string]*providers.DeviceMetadata, len(p.devices)+1)
+	for k, v := range p.devices {
+		devices[k] = v
+	}
+
+	return devices
+}
+
+// Persists metadata and replaces in-memory copy.
+func (p *provider) save(devices map[string]*providers.DeviceMetadata) error {
+	if "" != p.location {
+		fileData, err := yaml.Marshal(devices)
+		if err != nil {
+			p.logger.Error("Failed to marshal metadata", err, common.LogSystemToken, logSystem)
+			return errors.Wrap(err, "yaml marshal failed")
+		}
+
+		err = ioutil.WriteFile(p.location, fileData, 0600)
+		if err != nil {
+			p.logger.Error("Failed to write metadata file", err, common.LogSystemToken, logSystem)
+			return errors.Wrap(err, "file write failed")
+		}
+	}
+
+	p.devices = devices
+	return nil
+}
+
+// Checks whether metadata has no user-defined data.
+func isEmpty(m *providers.DeviceMetadata) bool {
+	return "" == m.Name && "" == m.Icon && 0 == len(m.Aliases) && 0 == len(m.Tags) && !m.Hidden
+}
+
+// Returns a sanitized copy of metadata.
+func copyMetadata(m *providers.DeviceMetadata) *providers.DeviceMetadata {
+	return &providers.DeviceMetadata{
+		Name:    m.Name,
+		Icon:    m.Icon,
+		Hidden:  m.Hidden,
+		Aliases: uniqueStrings(m.Aliases),
+		Tags:    uniqueStrings(m.Tags),
+
+		FirstSeen:   m.FirstSeen,
+		PreviousIDs: uniqueStrings(m.PreviousIDs),
+	}
+}
+
+// Returns sorted slice without duplicates and empty values.
+func uniqueStrings(data []string) []string {
+	if 0 == len(data) {
+		return nil
+	}
+
+	res := make([]string, 0, len(data))
+	for _, v := range data {
+		if "" != v && !helpers.SliceContainsString(res, v) {
+			res = append(res, v)
+		}
+	}
+
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,178 @@
+package metadata
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gobwas/glob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/providers"
+)
+
+// Creates a new metadata provider with temporary file.
+func getProvider(t *testing.T) (providers.IMetadataProvider, string, func()) {
+	dir, err := ioutil.TempDir("", "metadata")
+	require.NoError(t, err, "temp dir")
+
+	location := filepath.Join(dir, "_metadata.yaml")
+	p := NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+
+	return p, location, func() {
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+}
+
+// Tests metadata persistence.
+func TestPersistence(t *testing.T) {
+	p, location, cleanup := getProvider(t)
+	defer cleanup()
+
+	assert.Equal(t, 0, len(p.All()), "empty")
+
+	err := p.Set("dev1", &providers.DeviceMetadata{
+		Name:    "Lamp",
+		Aliases: []string{"lamp", "", "desk", "lamp"},
+		Tags:    []string{"office"},
+	})
+	require.NoError(t, err, "set dev1")
+	require.NoError(t, p.Set("dev2", &providers.DeviceMetadata{Hidden: true}), "set dev2")
+
+	loaded := NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+	require.Equal(t, 2, len(loaded.All()), "loaded")
+
+	m := loaded.Get("dev1")
+	require.NotNil(t, m, "dev1")
+	assert.Equal(t, "Lamp", m.Name, "name")
+	assert.Equal(t, []string{"desk", "lamp"}, m.Aliases, "aliases")
+	assert.True(t, loaded.Get("dev2").Hidden, "hidden")
+
+	m.Name = "changed"
+	assert.Equal(t, "Lamp", loaded.Get("dev1").Name, "copy")
+
+	require.NoError(t, loaded.Set("dev2", &providers.DeviceMetadata{}), "empty")
+	require.NoError(t, loaded.Delete("dev3"), "unknown")
+	assert.Nil(t, loaded.Get("dev2"), "deleted")
+
+	reloaded := NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+	assert.Equal(t, 1, len(reloaded.All()), "reloaded")
+}
+
+// Tests broken metadata file.
+func TestBrokenFile(t *testing.T) {
+	_, location, cleanup := getProvider(t)
+	defer cleanup()
+
+	require.NoError(t, ioutil.WriteFile(location, []byte("dev1:\ndev2: [\n"), 0600), "write")
+	p := NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+	assert.Equal(t, 0, len(p.All()), "broken")
+
+	require.NoError(t, ioutil.WriteFile(location, []byte("dev1:\ndev2:\n  name: test\n"), 0600), "write")
+	p = NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+	assert.Equal(t, 1, len(p.All()), "nil records")
+}
+
+// Tests devices matching.
+func TestMatch(t *testing.T) {
+	p := NewEmptyMetadataProvider()
+	require.NoError(t, p.Set("hue.light.1", &providers.DeviceMetadata{
+		Aliases: []string{"kitchen_lamp"},
+		Tags:    []string{"kitchen", "lights"},
+	}))
+
+	data := map[string]bool{
+		"hue.*":        true,
+		"kitchen_*":    true,
+		"tag:kitchen":  true,
+		"tag:light?":   true,
+		"tag:bedroom":  false,
+		"kitchen":      false,
+		"living_room*": false,
+	}
+
+	for k, v := range data {
+		assert.Equal(t, v, p.Match(glob.MustCompile(k), "hue.light.1"), k)
+	}
+
+	assert.False(t, p.Match(glob.MustCompile("tag:kitchen"), "hue.light.2"), "unknown device")
+}
+
+// Tests devices registration and renaming.
+func TestRegisterAndRename(t *testing.T) {
+	p, location, cleanup := getProvider(t)
+	defer cleanup()
+
+	assert.True(t, p.Register("old"), "first register")
+	assert.False(t, p.Register("old"), "second register")
+	require.NoError(t, p.Set("old", &providers.DeviceMetadata{Name: "Lamp", Tags: []string{"office"}}), "set")
+	require.NoError(t, p.Delete("old"), "delete")
+	require.NotNil(t, p.Get("old"), "registry is kept")
+	assert.Equal(t, "", p.Get("old").Name, "user data is removed")
+	firstSeen := p.Get("old").FirstSeen
+	assert.True(t, firstSeen > 0, "first seen")
+
+	require.NoError(t, p.Set("old", &providers.DeviceMetadata{Name: "Lamp", Tags: []string{"office"}}), "set")
+	assert.True(t, p.Register("new"), "new register")
+	assert.Error(t, p.Rename("new", "new"), "same ID")
+	assert.IsType(t, &ErrUnknownDevice{}, p.Rename("unknown", "new"), "unknown ID")
+	assert.Nil(t, p.Get("new").PreviousIDs, "unknown ID is added")
+	require.NoError(t, p.Rename("old", "new"), "rename")
+
+	reloaded := NewMetadataProvider(&ConstructMetadata{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: location,
+	})
+	assert.Nil(t, reloaded.Get("old"), "old")
+	m := reloaded.Get("new")
+	require.NotNil(t, m, "new")
+	assert.Equal(t, "Lamp", m.Name, "name")
+	assert.Equal(t, []string{"old"}, m.PreviousIDs, "previous")
+	assert.Equal(t, firstSeen, m.FirstSeen, "first seen")
+	assert.True(t, reloaded.Match(glob.MustCompile("old"), "new"), "match previous")
+
+	require.NoError(t, reloaded.Rename("new", "old"), "rename back")
+	assert.Equal(t, []string{"new"}, reloaded.Get("old").PreviousIDs, "previous after rename back")
+	assert.True(t, reloaded.Match(glob.MustCompile("new"), "old"), "match after rename back")
+	assert.True(t, reloaded.Register("new"), "register previous")
+	assert.False(t, reloaded.Match(glob.MustCompile("new"), "old"), "match registered previous")
+}
+
+// Tests metadata changes notifications.
+func TestNotifications(t *testing.T) {
+	fanOut := mocks.FakeNewFanOut()
+	_, updates := fanOut.SubscribeMetadataUpdates()
+	p := NewMetadataProvider(&ConstructMetadata{
+		Logger: mocks.FakeNewLogger(nil),
+		FanOut: fanOut,
+	})
+
+	require.True(t, p.Register("dev1"), "register")
+	require.NoError(t, p.Set("dev1", &providers.DeviceMetadata{Tags: []string{"kitchen"}}), "set")
+	require.NoError(t, p.Rename("dev1", "dev2"), "rename")
+	require.NoError(t, p.Delete("dev3"), "delete unknown")
+
+	received := make([]string, 0)
+	for len(updates) > 0 {
+		received = append(received, <-updates)
+	}
+
+	assert.Equal(t, []string{"dev1", "dev1", "dev2"}, received, "notifications")
+}
@@ -0,0 +1,23 @@
+package snapshot
+
+import "fmt"
+
+// ErrUnknownSnapshot defines unknown snapshot error.
+type ErrUnknownSnapshot struct {
+	DeviceID string
+	ID       string
+}
+
+// Error formats output.
+func (e *ErrUnknownSnapshot) Error() string {
+	return fmt.Sprintf("snapshot %s of device %s is unknown", e.ID, e.DeviceID)
+}
+
+// ErrDisabled defines disabled snapshots archive error.
+type ErrDisabled struct {
+}
+
+// Error formats output.
+func (e *ErrDisabled) Error() string {
+	return "snapshots archive is disabled"
+}
@@ -0,0 +1,208 @@
+// Package snapshot contains camera snapshots archive.
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/utils"
+)
+
+const (
+	// Logger system representation.
+	logSystem = "snapshot"
+	// Extension of the snapshot file.
+	snapshotExt = ".jpg"
+)
+
+// Snapshots provider.
+// Every device has its own folder with pictures, named by the time they were received.
+type provider struct {
+	sync.Mutex
+
+	location string
+	maxCount int
+	maxAge   int64
+	logger   common.ILoggerProvider
+	lastIDs  map[string]int64
+}
+
+// ConstructSnapshot has data required for a new snapshots provider.
+type ConstructSnapshot struct {
+	Logger   common.ILoggerProvider
+	Location string
+	MaxCount int
+	MaxAge   int
+}
+
+// NewEmptySnapshotProvider returns snapshots provider, which doesn't store anything.
+// It is used on workers and when archive is disabled.
+func NewEmptySnapshotProvider() providers.ISnapshotProvider {
+	return &provider{}
+}
+
+// NewSnapshotProvider returns a new snapshots provider, persisted in the folder.
+// Max age is set in hours.
+func NewSnapshotProvider(ctor *ConstructSnapshot) providers.ISnapshotProvider {
+	err := os.MkdirAll(ctor.Location, 0700)
+	if err != nil {
+		ctor.Logger.Error("Failed to create snapshots folder", err, common.LogSystemToken, logSystem)
+		return NewEmptySnapshotProvider()
+	}
+
+	return &provider{
+		location: ctor.Location,
+		maxCount: ctor.MaxCount,
+		maxAge:   int64(ctor.MaxAge) * 60 * 60 * 1000,
+		logger:   ctor.Logger,
+		lastIDs:  make(map[string]int64),
+	}
+}
+
+// Add stores a new camera picture and removes expired ones.
+func (p *provider) Add(deviceID string, picture []byte) error {
+	if "" == p.location {
+		return nil
+	}
+
+	if !isValidName(deviceID) {
+		return &ErrUnknownSnapshot{DeviceID: deviceID}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	err := os.MkdirAll(filepath.Join(p.location, deviceID), 0700)
+	if err != nil {
+		p.logger.Error("Failed to create device snapshots folder", err, common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID)
+		return errors.Wrap(err, "mkdir failed")
+	}
+
+	// IDs are always increasing, otherwise pruned ID could be re-used and treated as the oldest one.
+	id := utils.TimeNowMs()
+	if last, ok := p.lastIDs[deviceID]; ok && id <= last {
+		id = last + 1
+	}
+
+	for {
+		_, err = os.Stat(p.getFileName(deviceID, id))
+		if os.IsNotExist(err) {
+			break
+		}
+		id++
+	}
+	p.lastIDs[deviceID] = id
+
+	err = ioutil.WriteFile(p.getFileName(deviceID, id), picture, 0600)
+	if err != nil {
+		p.logger.Error("Failed to write snapshot", err, common.LogSystemToken, logSystem,
+			common.LogIDToken, deviceID)
+		return errors.Wrap(err, "file write failed")
+	}
+
+	p.prune(deviceID)
+	return nil
+}
+
+// List returns device snapshots, newest first.
+func (p *provider) List(deviceID string) []*providers.Snapshot {
+	if "" == p.location || !isValidName(deviceID) {
+		return make([]*providers.Snapshot, 0)
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	return p.prune(deviceID)
+}
+
+// Get returns a single snapshot picture.
+func (p *provider) Get(deviceID string, snapshotID string) ([]byte, error) {
+	if "" == p.location {
+		return nil, &ErrDisabled{}
+	}
+
+	id, err := strconv.ParseInt(snapshotID, 10, 64)
+	if err != nil || !isValidName(deviceID) {
+		return nil, &ErrUnknownSnapshot{DeviceID: deviceID, ID: snapshotID}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	data, err := ioutil.ReadFile(p.getFileName(deviceID, id))
+	if err != nil {
+		return nil, &ErrUnknownSnapshot{DeviceID: deviceID, ID: snapshotID}
+	}
+
+	return data, nil
+}
+
+// Removes snapshots exceeding max count or max age.
+// Returns remaining snapshots, newest first.
+func (p *provider) prune(deviceID string) []*providers.Snapshot {
+	snapshots := make([]*providers.Snapshot, 0)
+	files, err := ioutil.ReadDir(filepath.Join(p.location, deviceID))
+	if err != nil {
+		return snapshots
+	}
+
+	for _, v := range files {
+		if v.IsDir() || !strings.HasSuffix(v.Name(), snapshotExt) {
+			continue
+		}
+
+		id, err := strconv.ParseInt(strings.TrimSuffix(v.Name(), snapshotExt), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		snapshots = append(snapshots, &providers.Snapshot{
+			ID:   strconv.FormatInt(id, 10),
+			Time: id,
+			Size: v.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Time > snapshots[j].Time
+	})
+
+	oldest := utils.TimeNowMs() - p.maxAge
+	for ii, v := range snapshots {
+		if (p.maxCount <= 0 || ii < p.maxCount) && (p.maxAge <= 0 || v.Time >= oldest) {
+			continue
+		}
+
+		for _, expired := range snapshots[ii:] {
+			err = os.Remove(p.getFileName(deviceID, expired.Time))
+			if err != nil {
+				p.logger.Error("Failed to remove expired snapshot", err, common.LogSystemToken, logSystem,
+					common.LogIDToken, deviceID)
+			}
+		}
+
+		return snapshots[:ii]
+	}
+
+	return snapshots
+}
+
+// Returns snapshot file name.
+func (p *provider) getFileName(deviceID string, id int64) string {
+	return filepath.Join(p.location, deviceID, strconv.FormatInt(id, 10)+snapshotExt)
+}
+
+// Checks whether device ID can be used as a folder name.
+func isValidName(deviceID string) bool {
+	return "" != deviceID && !strings.HasPrefix(deviceID, ".") && filepath.Base(deviceID) == deviceID
+}
@@ -0,0 +1,101 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/utils"
+)
+
+// Creates a new snapshots provider with temporary folder.
+func getProvider(t *testing.T, maxCount int, maxAge int) (providers.ISnapshotProvider, string, func()) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err, "temp dir")
+
+	p := NewSnapshotProvider(&ConstructSnapshot{
+		Logger:   mocks.FakeNewLogger(nil),
+		Location: dir,
+		MaxCount: maxCount,
+		MaxAge:   maxAge,
+	})
+
+	return p, dir, func() {
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+}
+
+// Tests adding and reading snapshots.
+func TestSnapshots(t *testing.T) {
+	p, _, cleanup := getProvider(t, 10, 1)
+	defer cleanup()
+
+	assert.Equal(t, 0, len(p.List("cam")), "empty")
+	require.NoError(t, p.Add("cam", []byte("first")), "first")
+	require.NoError(t, p.Add("cam", []byte("second")), "second")
+
+	list := p.List("cam")
+	require.Equal(t, 2, len(list), "list")
+	assert.True(t, list[0].Time > list[1].Time, "order")
+	assert.Equal(t, int64(6), list[0].Size, "size")
+
+	data, err := p.Get("cam", list[0].ID)
+	require.NoError(t, err, "get")
+	assert.Equal(t, "second", string(data), "data")
+
+	_, err = p.Get("cam", "1")
+	assert.Error(t, err, "unknown snapshot")
+	_, err = p.Get("cam", "../cam")
+	assert.Error(t, err, "wrong snapshot")
+	assert.Equal(t, 0, len(p.List("other")), "other device")
+}
+
+// Tests retention by count and age.
+func TestRetention(t *testing.T) {
+	p, dir, cleanup := getProvider(t, 3, 1)
+	defer cleanup()
+
+	for ii := 0; ii < 5; ii++ {
+		require.NoError(t, p.Add("cam", []byte{byte(ii)}), "add %d", ii)
+	}
+
+	list := p.List("cam")
+	require.Equal(t, 3, len(list), "count")
+	data, err := p.Get("cam", list[2].ID)
+	require.NoError(t, err, "oldest")
+	assert.Equal(t, []byte{2}, data, "oldest data")
+
+	old := strconv.FormatInt(utils.TimeNowMs()-2*60*60*1000, 10)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cam", old+snapshotExt), []byte{1}, 0600), "old")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "cam", "wrong.txt"), []byte{1}, 0600), "wrong")
+
+	p.(*provider).maxCount = 10
+	assert.Equal(t, 3, len(p.List("cam")), "age")
+	_, err = os.Stat(filepath.Join(dir, "cam", old+snapshotExt))
+	assert.True(t, os.IsNotExist(err), "old file")
+}
+
+// Tests wrong device IDs and disabled archive.
+func TestWrongSnapshots(t *testing.T) {
+	p, _, cleanup := getProvider(t, 3, 1)
+	defer cleanup()
+
+	for _, v := range []string{"", ".", "..", "../cam", "cam/1"} {
+		assert.Error(t, p.Add(v, []byte{1}), "add %s", v)
+		assert.Equal(t, 0, len(p.List(v)), "list %s", v)
+		_, err := p.Get(v, "1")
+		assert.Error(t, err, "get %s", v)
+	}
+
+	e := NewEmptySnapshotProvider()
+	assert.NoError(t, e.Add("cam", []byte{1}), "empty add")
+	assert.Equal(t, 0, len(e.List("cam")), "empty list")
+	_, err := e.Get("cam", "1")
+	assert.Error(t, err, "empty get")
+}
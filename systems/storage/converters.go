@@ -2,6 +2,7 @@ package storage
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"go-home.io/x/server/plugins/common"
@@ -12,12 +13,19 @@ import (
 // PropertySave converts actual property before storing into the database.
 func PropertySave(property enums.Property, value interface{}) (interface{}, error) {
 	// Something we don't care to store
-	if property == enums.PropScenes || property == enums.PropSensorType {
+	switch property {
+	case enums.PropScenes, enums.PropSources, enums.PropPresets, enums.PropSensorType:
 		return nil, nil
 	}
 
 	switch helpers.GetPropertyType(property) {
-	case helpers.PropEnum, helpers.PropBool, helpers.PropString, helpers.PropStringSlice:
+	case helpers.PropEnum:
+		if s, ok := value.(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+
+		return value, nil
+	case helpers.PropBool, helpers.PropString, helpers.PropStringSlice:
 		return value, nil
 	case helpers.PropPercent:
 		return value.(common.Percent).Value, nil
@@ -52,6 +52,42 @@ func TestConverters(t *testing.T) {
 			Property: enums.PropOn,
 			TwoWay:   true,
 		},
+		{
+			In:       []string{"breeze", "sleep"},
+			Expected: nil,
+			Property: enums.PropPresets,
+			TwoWay:   false,
+		},
+		{
+			In:       enums.HvacModeCool,
+			Expected: "cool",
+			Property: enums.PropHvacMode,
+			TwoWay:   false,
+		},
+		{
+			In:       "heating",
+			Expected: "heating",
+			Property: enums.PropHvacAction,
+			TwoWay:   true,
+		},
+		{
+			In:       common.Percent{Value: 40},
+			Expected: uint8(40),
+			Property: enums.PropPosition,
+			TwoWay:   false,
+		},
+		{
+			In:       enums.CoverOpening,
+			Expected: "opening",
+			Property: enums.PropCoverStatus,
+			TwoWay:   false,
+		},
+		{
+			In:       common.Float{Value: 21.5},
+			Expected: 21.5,
+			Property: enums.PropTargetTemperature,
+			TwoWay:   false,
+		},
 	}
 
 	for _, v := range data {
@@ -0,0 +1,10 @@
+package storage
+
+// ErrRenameNotSupported defines storage plugin without history migration support.
+type ErrRenameNotSupported struct {
+}
+
+// Error formats output.
+func (*ErrRenameNotSupported) Error() string {
+	return "storage plugin doesn't support history migration"
+}
@@ -161,6 +161,28 @@ func (s *provider) History(deviceID string) map[enums.Property]map[int64]interfa
 	return result
 }
 
+// Rename migrates device history to a new ID.
+func (s *provider) Rename(from string, to string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if nil == s.plugin {
+		return nil
+	}
+
+	renamer, ok := s.plugin.(storage.IStorageRenamer)
+	if !ok {
+		return &ErrRenameNotSupported{}
+	}
+
+	err := renamer.Rename(from, to)
+	if err != nil {
+		s.logger.Error("Failed to migrate device history", err, common.LogIDToken, from)
+	}
+
+	return err
+}
+
 // Processes device update message.
 func (s *provider) processDeviceUpdate(msg *common.MsgDeviceUpdate) {
 	s.Lock()
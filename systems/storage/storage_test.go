@@ -206,3 +206,37 @@ include:
 	time.Sleep(1 * time.Second)
 	assert.Equal(t, 1, pl.invokes)
 }
+
+// Fake plugin with history migration support.
+type fakeRenamePlugin struct {
+	fakePlugin
+	from string
+	to   string
+}
+
+func (f *fakeRenamePlugin) Rename(from string, to string) error {
+	f.from = from
+	f.to = to
+	return nil
+}
+
+// Tests history migration.
+func TestRename(t *testing.T) {
+	assert.NoError(t, NewEmptyStorageProvider().Rename("old", "new"), "empty")
+
+	ctor := &ConstructStorage{
+		PluginLogger: mocks.FakeNewLogger(nil),
+		Loader:       mocks.FakeNewPluginLoader(&fakePlugin{}),
+		Provider:     "test",
+		Secret:       mocks.FakeNewSecretStore(nil, true),
+	}
+
+	err := NewStorageProvider(ctor).Rename("old", "new")
+	assert.IsType(t, &ErrRenameNotSupported{}, err, "not supported")
+
+	pl := &fakeRenamePlugin{}
+	ctor.Loader = mocks.FakeNewPluginLoader(pl)
+	require.NoError(t, NewStorageProvider(ctor).Rename("old", "new"), "rename")
+	assert.Equal(t, "old", pl.from, "from")
+	assert.Equal(t, "new", pl.to, "to")
+}
@@ -0,0 +1,13 @@
+package template
+
+import "fmt"
+
+// ErrUnknownDeviceType defines unsupported template device type.
+type ErrUnknownDeviceType struct {
+	Type string
+}
+
+// Error formats output.
+func (e *ErrUnknownDeviceType) Error() string {
+	return fmt.Sprintf("device type %s is not supported by templates", e.Type)
+}
@@ -0,0 +1,330 @@
+// Package template contains template devices provider.
+// Template devices are virtual devices with state computed from other devices.
+package template
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/plugins/helpers"
+	"go-home.io/x/server/providers"
+	"go-home.io/x/server/systems"
+	"go-home.io/x/server/systems/logger"
+	"go-home.io/x/server/utils"
+	"gopkg.in/yaml.v2"
+)
+
+// Implements template device provider.
+type provider struct {
+	sync.Mutex
+
+	internalID string
+	name       string
+	deviceType enums.DeviceType
+
+	devicesExp   []glob.Glob
+	expressions  map[enums.Property]helpers.ITemplateExpression
+	updatesChan  chan *common.MsgDeviceUpdate
+	metadataChan chan string
+	logger       common.ILoggerProvider
+	server       providers.IServerProvider
+	metadata     providers.IMetadataProvider
+
+	devices   map[string]map[enums.Property]interface{}
+	unmatched []string
+	state     map[string]interface{}
+}
+
+// Template device settings.
+type settings struct {
+	Name       string            `yaml:"name"`
+	DeviceType string            `yaml:"type"`
+	Devices    []string          `yaml:"devices"`
+	Properties map[string]string `yaml:"properties"`
+}
+
+// ConstructTemplate has data required for instantiating a new template device.
+type ConstructTemplate struct {
+	RawConfig []byte
+	Settings  providers.ISettingsProvider
+	Server    providers.IServerProvider
+}
+
+// NewTemplateProvider creates a new template device provider.
+func NewTemplateProvider(ctor *ConstructTemplate) (providers.ITemplateProvider, error) {
+	settings := &settings{}
+	err := yaml.Unmarshal(ctor.RawConfig, settings)
+	if err != nil {
+		ctor.Settings.SystemLogger().Error("Failed to load template device", err)
+		return nil, errors.Wrap(err, "yaml un-marshal failed")
+	}
+
+	logCtor := &logger.ConstructPluginLogger{
+		SystemLogger: ctor.Settings.PluginLogger(),
+		Provider:     systems.SysDevice.String(),
+		System:       "template",
+		ExtraFields: map[string]string{
+			common.LogNameToken: settings.Name,
+			common.LogIDToken:   getID(settings.Name),
+		},
+	}
+	log := logger.NewPluginLogger(logCtor)
+
+	deviceType := enums.DevSensor
+	if "" != settings.DeviceType {
+		deviceType, err = enums.DeviceTypeString(settings.DeviceType)
+		if err != nil || enums.DevGroup == deviceType || enums.DevUnknown == deviceType {
+			err = &ErrUnknownDeviceType{Type: settings.DeviceType}
+			log.Error("Failed to load template device", err)
+			return nil, err
+		}
+	}
+
+	provider := &provider{
+		logger:      log,
+		internalID:  getID(settings.Name),
+		name:        settings.Name,
+		deviceType:  deviceType,
+		devicesExp:  make([]glob.Glob, 0),
+		expressions: make(map[enums.Property]helpers.ITemplateExpression),
+		devices:     make(map[string]map[enums.Property]interface{}),
+		unmatched:   make([]string, 0),
+		state:       make(map[string]interface{}),
+		server:      ctor.Server,
+		metadata:    ctor.Settings.Metadata(),
+	}
+
+	for _, v := range settings.Devices {
+		exp, err := glob.Compile(v)
+		if err != nil {
+			provider.logger.Error("Failed to compile template regexp, skipping", err)
+			continue
+		}
+
+		provider.devicesExp = append(provider.devicesExp, exp)
+	}
+
+	parser := helpers.NewParser()
+	for k, v := range settings.Properties {
+		prop, err := enums.PropertyString(k)
+		if err != nil || !prop.IsPropertyAllowed(deviceType) {
+			provider.logger.Warn("Property is not supported by device type, skipping",
+				common.LogDevicePropertyToken, k, common.LogDeviceTypeToken, deviceType.String())
+			continue
+		}
+
+		exp, err := parser.Compile(v)
+		if err != nil {
+			provider.logger.Error("Failed to compile template expression, skipping", err,
+				common.LogDevicePropertyToken, k)
+			continue
+		}
+
+		provider.expressions[prop] = exp
+	}
+
+	fanOut := ctor.Settings.FanOut()
+	_, provider.updatesChan = fanOut.SubscribeDeviceUpdates()
+	_, provider.metadataChan = fanOut.SubscribeMetadataUpdates()
+	go provider.deviceUpdates()
+	go provider.metadataUpdates()
+
+	return provider, nil
+}
+
+// ID returns template device internal ID.
+func (p *provider) ID() string {
+	return p.internalID
+}
+
+// Devices returns list of devices used by the template.
+func (p *provider) Devices() []string {
+	p.Lock()
+	defer p.Unlock()
+
+	ids := make([]string, 0)
+	for k := range p.devices {
+		ids = append(ids, k)
+	}
+
+	return ids
+}
+
+// Subscribes for devices updates.
+func (p *provider) deviceUpdates() {
+	for msg := range p.updatesChan {
+		go p.processDeviceUpdates(msg)
+	}
+}
+
+// Processes devices updates.
+func (p *provider) processDeviceUpdates(msg *common.MsgDeviceUpdate) {
+	p.Lock()
+	defer p.Unlock()
+
+	if msg.ID == p.internalID || enums.DevGroup == msg.Type {
+		return
+	}
+
+	if msg.Removed {
+		p.unmatched = helpers.SliceRemoveString(p.unmatched, msg.ID)
+		if _, ok := p.devices[msg.ID]; !ok {
+			return
+		}
+
+		delete(p.devices, msg.ID)
+		p.pushUpdate()
+		return
+	}
+
+	if helpers.SliceContainsString(p.unmatched, msg.ID) {
+		return
+	}
+
+	state, ok := p.devices[msg.ID]
+	if !ok {
+		if !p.isMatched(msg.ID) {
+			p.unmatched = append(p.unmatched, msg.ID)
+			return
+		}
+
+		state = make(map[enums.Property]interface{})
+		p.devices[msg.ID] = state
+	}
+
+	for k, v := range msg.State {
+		state[k] = v
+	}
+
+	p.pushUpdate()
+}
+
+// Subscribes for devices metadata updates.
+func (p *provider) metadataUpdates() {
+	for deviceID := range p.metadataChan {
+		go p.processMetadataUpdates(deviceID)
+	}
+}
+
+// Re-evaluates device membership, since aliases or tags might have changed.
+func (p *provider) processMetadataUpdates(deviceID string) {
+	p.Lock()
+	defer p.Unlock()
+
+	if deviceID == p.internalID {
+		return
+	}
+
+	p.unmatched = helpers.SliceRemoveString(p.unmatched, deviceID)
+	_, ok := p.devices[deviceID]
+	if !p.isMatched(deviceID) {
+		if ok {
+			delete(p.devices, deviceID)
+			p.pushUpdate()
+		}
+
+		return
+	}
+
+	if ok {
+		return
+	}
+
+	kd := p.server.GetDevice(deviceID)
+	state := p.server.GetDeviceState(deviceID)
+	if nil == kd || nil == state || enums.DevGroup == kd.Type {
+		return
+	}
+
+	p.devices[deviceID] = state
+	p.pushUpdate()
+}
+
+// Checks whether device matches any of the template expressions.
+func (p *provider) isMatched(deviceID string) bool {
+	for _, v := range p.devicesExp {
+		if p.metadata.Match(v, deviceID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evaluates template and sends changed state to the master.
+func (p *provider) pushUpdate() {
+	newState := p.evaluate()
+	if 0 == len(newState) || reflect.DeepEqual(newState, p.state) {
+		return
+	}
+
+	p.state = newState
+	p.server.PushMasterDeviceUpdate(&providers.MasterDeviceUpdate{
+		Type:     p.deviceType,
+		Name:     p.name,
+		ID:       p.internalID,
+		Commands: make([]string, 0),
+		State:    p.state,
+	})
+}
+
+// Evaluates template expressions.
+// Every known property is passed as a parameter with values from all matched devices.
+func (p *provider) evaluate() map[string]interface{} {
+	params := make(map[string]interface{})
+	for _, s := range p.devices {
+		for k, v := range s {
+			val := helpers.PlainValueProperty(v, k)
+			if str, ok := val.(fmt.Stringer); ok {
+				val = str.String()
+			}
+
+			values, ok := params[k.String()]
+			if !ok {
+				values = make([]interface{}, 0)
+			}
+
+			params[k.String()] = append(values.([]interface{}), val)
+		}
+	}
+
+	state := make(map[string]interface{})
+	for k, v := range p.expressions {
+		val, err := v.Format(params)
+		if err != nil {
+			p.logger.Debug("Failed to evaluate template expression",
+				common.LogDevicePropertyToken, k.String(), common.LogErrorToken, err.Error())
+			continue
+		}
+
+		state[k.String()] = fixValue(val, k)
+	}
+
+	return state
+}
+
+// Converts ID.
+func getID(name string) string {
+	return fmt.Sprintf("template.%s", utils.NormalizeDeviceName(name))
+}
+
+// Rounds numeric values for integer properties.
+func fixValue(val interface{}, prop enums.Property) interface{} {
+	f, ok := val.(float64)
+	if !ok {
+		return val
+	}
+
+	switch helpers.GetPropertyType(prop) {
+	case helpers.PropPercent, helpers.PropInt:
+		return int(math.Round(f))
+	default:
+		return f
+	}
+}
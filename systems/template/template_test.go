@@ -0,0 +1,216 @@
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
+	"go-home.io/x/server/plugins/device/enums"
+	"go-home.io/x/server/providers"
+)
+
+// Creates a new template provider.
+func getProvider(t *testing.T, config string) (providers.ITemplateProvider,
+	providers.IInternalFanOutProvider, mocks.IFakeServer) {
+	s := mocks.FakeNewSettings(nil, false, nil, nil)
+	srv := mocks.FakeNewServer(nil)
+
+	ctor := &ConstructTemplate{
+		Settings:  s,
+		Server:    srv.(providers.IServerProvider),
+		RawConfig: []byte(config),
+	}
+
+	p, err := NewTemplateProvider(ctor)
+	require.NoError(t, err)
+
+	return p, s.FanOut(), srv
+}
+
+// Tests computed sensor state.
+func TestSensorTemplate(t *testing.T) {
+	var config = `
+system: device
+provider: template
+name: upstairs
+devices:
+  - upstairs*
+properties:
+  temperature: avg(temperature)
+  power: sum(power)
+  on: any(on)
+`
+	p, f, srv := getProvider(t, config)
+	assert.Equal(t, "template.upstairs", p.ID())
+
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "downstairs.sensor.test",
+		State: map[enums.Property]interface{}{
+			enums.PropTemperature: common.Float{Value: 30},
+		},
+	}
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "upstairs_bedroom.sensor.test",
+		State: map[enums.Property]interface{}{
+			enums.PropTemperature: common.Float{Value: 20},
+			enums.PropPower:       common.Float{Value: 100},
+			enums.PropOn:          false,
+		},
+	}
+	time.Sleep(100 * time.Millisecond)
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "upstairs_office.sensor.test",
+		State: map[enums.Property]interface{}{
+			enums.PropTemperature: common.Float{Value: 22},
+			enums.PropPower:       common.Float{Value: 50},
+			enums.PropOn:          true,
+		},
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, 2, len(p.Devices()))
+	update := srv.LastUpdate()
+	require.NotNil(t, update)
+	assert.Equal(t, enums.DevSensor, update.Type)
+	assert.Equal(t, "upstairs", update.Name)
+	assert.Equal(t, 21.0, update.State[enums.PropTemperature.String()])
+	assert.Equal(t, 150.0, update.State[enums.PropPower.String()])
+	assert.Equal(t, true, update.State[enums.PropOn.String()])
+
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "upstairs_office.sensor.test",
+		State: map[enums.Property]interface{}{
+			enums.PropOn: false,
+		},
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, false, srv.LastUpdate().State[enums.PropOn.String()])
+	assert.Equal(t, 150.0, srv.LastUpdate().State[enums.PropPower.String()])
+
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:      "upstairs_office.sensor.test",
+		Removed: true,
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, len(p.Devices()), "removed device")
+	assert.Equal(t, 100.0, srv.LastUpdate().State[enums.PropPower.String()], "removed device state")
+}
+
+// Tests that template ignores own and group updates.
+func TestIgnoredUpdates(t *testing.T) {
+	var config = `
+name: all
+devices:
+  - "*"
+properties:
+  on: any(on)
+`
+	p, f, srv := getProvider(t, config)
+
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:    "template.all",
+		State: map[enums.Property]interface{}{enums.PropOn: true},
+	}
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:    "group.lights",
+		Type:  enums.DevGroup,
+		State: map[enums.Property]interface{}{enums.PropOn: true},
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, 0, len(p.Devices()))
+	assert.Nil(t, srv.LastUpdate())
+}
+
+// Tests template device type and properties validation.
+func TestTemplateSettings(t *testing.T) {
+	var config = `
+name: windows
+type: switch
+devices:
+  - window*
+properties:
+  on: any(on)
+  temperature: avg(temperature)
+  power: sum(
+`
+	_, f, srv := getProvider(t, config)
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID: "window1",
+		State: map[enums.Property]interface{}{
+			enums.PropOn:          true,
+			enums.PropTemperature: common.Float{Value: 20},
+		},
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	update := srv.LastUpdate()
+	require.NotNil(t, update)
+	assert.Equal(t, enums.DevSwitch, update.Type)
+	assert.Equal(t, map[string]interface{}{enums.PropOn.String(): true}, update.State)
+}
+
+// Tests wrong settings.
+func TestWrongSettings(t *testing.T) {
+	data := []string{
+		"name: test\ndevices: devices:",
+		"name: test\ntype: unknown",
+		"name: test\ntype: group",
+	}
+
+	for _, v := range data {
+		s := mocks.FakeNewSettings(nil, false, nil, nil)
+		ctor := &ConstructTemplate{
+			Settings:  s,
+			Server:    mocks.FakeNewServer(nil).(providers.IServerProvider),
+			RawConfig: []byte(v),
+		}
+
+		_, err := NewTemplateProvider(ctor)
+		assert.Error(t, err, v)
+	}
+}
+
+// Tests membership changes with device metadata.
+func TestTemplateMetadata(t *testing.T) {
+	var config = `
+name: upstairs
+devices:
+  - tag:upstairs
+properties:
+  power: sum(power)
+`
+	s := mocks.FakeNewSettings(nil, false, nil, nil)
+	srv := mocks.FakeNewServer(nil)
+	p, err := NewTemplateProvider(&ConstructTemplate{
+		Settings:  s,
+		Server:    srv.(providers.IServerProvider),
+		RawConfig: []byte(config),
+	})
+	require.NoError(t, err)
+
+	f := s.FanOut()
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:    "bedroom.sensor.power",
+		State: map[enums.Property]interface{}{enums.PropPower: common.Float{Value: 100}},
+	}
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, len(p.Devices()), "unmatched device was added")
+
+	srv.AddDevice(&providers.KnownDevice{Type: enums.DevSensor})
+	srv.SetDeviceState(map[enums.Property]interface{}{enums.PropPower: common.Float{Value: 100}})
+	s.Metadata().Set("bedroom.sensor.power", &providers.DeviceMetadata{Tags: []string{"upstairs"}}) // nolint: gosec
+	f.ChannelInMetadataUpdates() <- "bedroom.sensor.power"
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, len(p.Devices()), "tag was not applied")
+	require.NotNil(t, srv.LastUpdate(), "update")
+	assert.Equal(t, 100.0, srv.LastUpdate().State[enums.PropPower.String()], "state")
+
+	s.Metadata().Delete("bedroom.sensor.power") // nolint: gosec
+	f.ChannelInMetadataUpdates() <- "bedroom.sensor.power"
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, len(p.Devices()), "tag was not removed")
+}
@@ -21,8 +21,10 @@ type location struct {
 	devices   []string
 	unmatched []string
 
-	devicesExp  []glob.Glob
-	updatesChan chan *common.MsgDeviceUpdate
+	devicesExp   []glob.Glob
+	updatesChan  chan *common.MsgDeviceUpdate
+	metadataChan chan string
+	metadata     providers.IMetadataProvider
 }
 
 // Devices returns list of devices assigned to a location.
@@ -53,8 +55,9 @@ type locationSettings struct {
 // ConstructLocation has data required for creating a new location.
 type ConstructLocation struct {
 	RawConfig []byte
-	FanOut    common.IFanOutProvider
+	FanOut    providers.IInternalFanOutProvider
 	Logger    common.ILoggerProvider
+	Metadata  providers.IMetadataProvider
 }
 
 // NewLocationProvider creates a new location.
@@ -72,6 +75,7 @@ func NewLocationProvider(ctor *ConstructLocation) (providers.ILocationProvider,
 		name:       settings.Name,
 		devicesExp: make([]glob.Glob, 0),
 		icon:       settings.Icon,
+		metadata:   ctor.Metadata,
 	}
 
 	for _, v := range settings.Devices {
@@ -85,7 +89,9 @@ func NewLocationProvider(ctor *ConstructLocation) (providers.ILocationProvider,
 	}
 
 	_, provider.updatesChan = ctor.FanOut.SubscribeDeviceUpdates()
+	_, provider.metadataChan = ctor.FanOut.SubscribeMetadataUpdates()
 	go provider.deviceUpdates()
+	go provider.metadataUpdates()
 	return provider, nil
 }
 
@@ -101,17 +107,65 @@ func (l *location) processDeviceUpdates(msg *common.MsgDeviceUpdate) {
 	l.Lock()
 	defer l.Unlock()
 
+	if msg.Removed {
+		l.devices = helpers.SliceRemoveString(l.devices, msg.ID)
+		l.unmatched = helpers.SliceRemoveString(l.unmatched, msg.ID)
+		return
+	}
+
 	if !msg.FirstSeen || helpers.SliceContainsString(l.unmatched, msg.ID) ||
 		helpers.SliceContainsString(l.devices, msg.ID) {
 		return
 	}
 
+	if l.isMatched(msg.ID) {
+		l.devices = append(l.devices, msg.ID)
+		return
+	}
+
+	l.unmatched = append(l.unmatched, msg.ID)
+}
+
+// Subscribes for devices metadata updates.
+func (l *location) metadataUpdates() {
+	for deviceID := range l.metadataChan {
+		go l.processMetadataUpdates(deviceID)
+	}
+}
+
+// Re-evaluates device membership, since aliases or tags might have changed.
+func (l *location) processMetadataUpdates(deviceID string) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.unmatched = helpers.SliceRemoveString(l.unmatched, deviceID)
+	if !l.isMatched(deviceID) {
+		l.devices = helpers.SliceRemoveString(l.devices, deviceID)
+		return
+	}
+
+	if !helpers.SliceContainsString(l.devices, deviceID) {
+		l.devices = append(l.devices, deviceID)
+	}
+}
+
+// Checks whether device matches any of the location expressions.
+func (l *location) isMatched(deviceID string) bool {
 	for _, v := range l.devicesExp {
-		if v.Match(msg.ID) {
-			l.devices = append(l.devices, msg.ID)
-			return
+		if l.match(v, deviceID) {
+			return true
 		}
 	}
 
-	l.unmatched = append(l.unmatched, msg.ID)
+	return false
+}
+
+// Checks whether device matches the expression.
+// Device aliases and tags are checked if metadata is available.
+func (l *location) match(exp glob.Glob, deviceID string) bool {
+	if nil == l.metadata {
+		return exp.Match(deviceID)
+	}
+
+	return l.metadata.Match(exp, deviceID)
 }
@@ -84,6 +84,23 @@ func (g *grSuite) TestDoubleIncorrect() {
 	assert.Equal(g.T(), 0, len(g.prov.Devices()), "second add")
 }
 
+// Tests device removal.
+func (g *grSuite) TestRemove() {
+	g.f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:        "device1",
+		FirstSeen: true,
+	}
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 1, len(g.prov.Devices()), "not added")
+
+	g.f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:      "device1",
+		Removed: true,
+	}
+	time.Sleep(1 * time.Second)
+	assert.Equal(g.T(), 0, len(g.prov.Devices()), "not removed")
+}
+
 // Tests ID.
 func (g *grSuite) TestID() {
 	assert.Equal(g.T(), "cabinet loc", g.prov.ID())
@@ -94,6 +111,42 @@ func TestGroupProvider(t *testing.T) {
 	suite.Run(t, new(grSuite))
 }
 
+// Tests membership changes with device metadata.
+func TestLocationMetadata(t *testing.T) {
+	var config = `
+system: ui
+provider: location
+name: kitchen
+devices:
+  - tag:kitchen
+`
+	s := mocks.FakeNewSettings(nil, false, nil, nil)
+	f := s.FanOut()
+	prov, err := NewLocationProvider(&ConstructLocation{
+		RawConfig: []byte(config),
+		FanOut:    f,
+		Metadata:  s.Metadata(),
+	})
+	assert.NoError(t, err, "location")
+
+	f.ChannelInDeviceUpdates() <- &common.MsgDeviceUpdate{
+		ID:        "device1",
+		FirstSeen: true,
+	}
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, 0, len(prov.Devices()), "unmatched device was added")
+
+	s.Metadata().Set("device1", &providers.DeviceMetadata{Tags: []string{"kitchen"}}) // nolint: gosec
+	f.ChannelInMetadataUpdates() <- "device1"
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, []string{"device1"}, prov.Devices(), "tag was not applied")
+
+	s.Metadata().Set("device1", &providers.DeviceMetadata{Tags: []string{"office"}}) // nolint: gosec
+	f.ChannelInMetadataUpdates() <- "device1"
+	time.Sleep(1 * time.Second)
+	assert.Equal(t, 0, len(prov.Devices()), "tag was not removed")
+}
+
 // Test wrong config.
 func TestWrongSettings(t *testing.T) {
 	var config = `ad`
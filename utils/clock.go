@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Estimated offset between local and master clocks in milliseconds.
+var busClockOffset int64
+
+// TimeNowMs returns epoch UTC in milliseconds.
+func TimeNowMs() int64 {
+	return time.Now().UTC().UnixNano() / int64(time.Millisecond)
+}
+
+// BusTimeNow returns epoch UTC adjusted to the master clock.
+// All service bus messages are using master's time.
+func BusTimeNow() int64 {
+	return (TimeNowMs() + atomic.LoadInt64(&busClockOffset)) / 1000
+}
+
+// SetBusClockOffset updates estimated offset between local and master clocks.
+func SetBusClockOffset(offsetMs int64) {
+	atomic.StoreInt64(&busClockOffset, offsetMs)
+}
+
+// GetBusClockOffset returns estimated offset between local and master clocks.
+func GetBusClockOffset() int64 {
+	return atomic.LoadInt64(&busClockOffset)
+}
@@ -50,6 +50,50 @@ func NormalizeDeviceName(raw string) string {
 	return replacer.Replace(raw)
 }
 
+// StringSimilarity returns similarity of two strings from 0 to 1, based on Levenshtein distance.
+func StringSimilarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if 0 == len(ra) && 0 == len(rb) {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for jj := range prev {
+		prev[jj] = jj
+	}
+
+	for ii := 1; ii <= len(ra); ii++ {
+		curr[0] = ii
+		for jj := 1; jj <= len(rb); jj++ {
+			cost := 1
+			if ra[ii-1] == rb[jj-1] {
+				cost = 0
+			}
+
+			curr[jj] = minInt(minInt(prev[jj]+1, curr[jj-1]+1), prev[jj-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+
+	return 1 - float64(prev[len(rb)])/float64(maxLen)
+}
+
+// Returns minimal integer.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
 // GetCurrentWorkingDir returns application working directory.
 func GetCurrentWorkingDir() string {
 	cwd, err := os.Getwd()
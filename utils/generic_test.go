@@ -82,3 +82,13 @@ func TestNormalizeDeviceName(t *testing.T) {
 		assert.Equal(t, v, NormalizeDeviceName(k), k)
 	}
 }
+
+// Tests strings similarity.
+func TestStringSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, StringSimilarity("", ""), "empty")
+	assert.Equal(t, 1.0, StringSimilarity("device", "device"), "equal")
+	assert.Equal(t, 0.0, StringSimilarity("abc", "xyz"), "different")
+	assert.Equal(t, 0.0, StringSimilarity("", "abc"), "one empty")
+	assert.InDelta(t, 0.6, StringSimilarity("light", "lihgt"), 0.01, "transposition")
+	assert.True(t, StringSimilarity("hue.light.kitchen", "hue.light.kitchen_1") > 0.8, "suffix")
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go-home.io/x/server/mocks"
+	"go-home.io/x/server/plugins/common"
 )
 
 type testStruct struct {
@@ -68,3 +69,16 @@ func TestFailedValidation(t *testing.T) {
 		assert.False(t, validator.Validate(v), "%d", k)
 	}
 }
+
+// Tests that zero percent is valid only for cover position.
+func TestZeroPercent(t *testing.T) {
+	validator := NewValidator(mocks.FakeNewLogger(nil))
+	assert.False(t, validator.Validate(&common.Percent{Value: 0}), "missing percent")
+	assert.False(t, validator.Validate(&common.Percent{Value: 101}), "over 100")
+
+	zero := uint8(0)
+	over := uint8(101)
+	assert.True(t, validator.Validate(&common.Position{Value: &zero}), "zero position")
+	assert.False(t, validator.Validate(&common.Position{Value: &over}), "position over 100")
+	assert.False(t, validator.Validate(&common.Position{}), "missing position")
+}
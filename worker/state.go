@@ -23,6 +23,8 @@ type IWorkerStateProvider interface {
 	DevicesAssignmentMessage(*bus.DeviceAssignmentMessage)
 	// Processing device command message.
 	DevicesCommandMessage(*bus.DeviceCommandMessage)
+	// Processing device resync message.
+	DeviceResyncMessage(*bus.DeviceResyncMessage)
 }
 
 var (
@@ -106,7 +108,10 @@ func (w *workerState) DevicesCommandMessage(msg *bus.DeviceCommandMessage) {
 	w.Logger.Debug("Received device command message", common.LogSystemToken, logSystem,
 		common.LogIDToken, msg.DeviceID, common.LogDeviceCommandToken, msg.Command.String())
 
+	w.mutex.Lock()
 	wrapper, ok := w.devices[msg.DeviceID]
+	w.mutex.Unlock()
+
 	if !ok {
 		w.Logger.Warn("Failed to find device on this worker", common.LogSystemToken, logSystem,
 			common.LogIDToken, msg.DeviceID,
@@ -114,7 +119,27 @@ func (w *workerState) DevicesCommandMessage(msg *bus.DeviceCommandMessage) {
 		return
 	}
 
-	wrapper.InvokeCommand(msg.Command, msg.Payload)
+	wrapper.QueueCommand(msg.Command, msg.Payload)
+}
+
+// DeviceResyncMessage sends device's full state, requested by server.
+// Keyframe is published by the same routine as regular updates, so versions stay ordered.
+func (w *workerState) DeviceResyncMessage(msg *bus.DeviceResyncMessage) {
+	w.mutex.Lock()
+	_, ok := w.devices[msg.DeviceID]
+	w.mutex.Unlock()
+
+	if !ok {
+		w.Logger.Warn("Failed to find device on this worker", common.LogSystemToken, logSystem,
+			common.LogIDToken, msg.DeviceID)
+		return
+	}
+
+	w.Logger.Debug("Re-sending device state", common.LogSystemToken, logSystem, common.LogIDToken, msg.DeviceID)
+	w.statusUpdatesChan <- &device.UpdateEvent{
+		ID:       msg.DeviceID,
+		Keyframe: true,
+	}
 }
 
 // Periodic checks to determine whether master is active.
@@ -135,6 +160,11 @@ func (w *workerState) start() {
 	for {
 		select {
 		case update := <-w.statusUpdatesChan:
+			if update.Removed {
+				w.removeDevice(update.ID)
+				break
+			}
+
 			w.mutex.Lock()
 			wrapper, ok := w.devices[update.ID]
 			w.mutex.Unlock()
@@ -144,9 +174,25 @@ func (w *workerState) start() {
 				break
 			}
 
-			w.mutex.Lock()
-			go w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates, wrapper.GetUpdateMessage())
-			w.mutex.Unlock()
+			if nil != update.Event {
+				w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates, bus.NewDeviceEventMessage(
+					update.ID, w.Settings.NodeID(), update.Event.Event, update.Event.Data))
+				break
+			}
+
+			var msg *bus.DeviceUpdateMessage
+			if update.Keyframe {
+				msg = wrapper.GetKeyframeMessage()
+			} else {
+				msg = wrapper.GetUpdateMessage()
+			}
+
+			if nil == msg {
+				break
+			}
+
+			// Publishing synchronously to preserve updates order.
+			w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates, msg)
 		case discover := <-w.discoveryChan:
 			w.mutex.Lock()
 			id := discover.Provider.ID()
@@ -161,6 +207,22 @@ func (w *workerState) start() {
 	}
 }
 
+// Unloads device, removed from the hub, and notifies master.
+func (w *workerState) removeDevice(deviceID string) {
+	w.mutex.Lock()
+	wrapper, ok := w.devices[deviceID]
+	delete(w.devices, deviceID)
+	w.mutex.Unlock()
+
+	if ok {
+		go w.tryUnload(wrapper)
+	}
+
+	w.Logger.Info("Device was removed", common.LogSystemToken, logSystem, common.LogIDToken, deviceID)
+	w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates,
+		bus.NewDeviceRemovedMessage(deviceID, w.Settings.NodeID()))
+}
+
 // Time-based logic.
 func (w *workerState) timeCycle() {
 	stale := time.Tick(15 * time.Second)
@@ -357,7 +419,8 @@ func (w *workerState) tryDeviceAssignmentLoad(a *bus.DeviceAssignment, ctor *dev
 	w.entityLoadNotification(ctor.ConfigName, true)
 	for _, v := range wrappers {
 		w.devices[v.ID()] = v
-		go w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates, v.GetUpdateMessage())
+		// Keyframe should be published before the first delta, which can't be processed until devices are loaded.
+		w.Settings.ServiceBus().Publish(busPlugin.ChDeviceUpdates, v.GetKeyframeMessage())
 	}
 }
 
@@ -26,6 +26,7 @@ type fakeDevicePlugin struct {
 	getSpecCalled bool
 	loadCalled    bool
 	updateCalled  bool
+	updates       int
 }
 
 func (f *fakeDevicePlugin) Init(*device.InitDataDevice) error {
@@ -59,8 +60,9 @@ func (f *fakeDevicePlugin) Load() (*device.SensorState, error) {
 
 func (f *fakeDevicePlugin) Update() (*device.SensorState, error) {
 	f.updateCalled = true
+	f.updates++
 	return &device.SensorState{
-		On: true,
+		On: 0 == f.updates%2,
 	}, f.updateError
 }
 
@@ -68,10 +70,12 @@ func (f *fakeDevicePlugin) Update() (*device.SensorState, error) {
 type fakeHub struct {
 	unloadCalled bool
 	disc         chan *device.DiscoveredDevices
+	removed      chan *device.RemovedDevice
 }
 
 func (f *fakeHub) FakeInit(data interface{}) {
 	f.disc = data.(*device.InitDataDevice).DeviceDiscoveredChan
+	f.removed = data.(*device.InitDataDevice).DeviceRemovedChan
 }
 
 func (f *fakeHub) Init(*device.InitDataDevice) error {
@@ -118,9 +122,17 @@ func (f *fakeHub) Disco(s *fakeSwitch) {
 	}
 }
 
+func (f *fakeHub) Remove(s *fakeSwitch) {
+	f.removed <- &device.RemovedDevice{
+		Type: enums.DevSwitch,
+		Name: s.GetName(),
+	}
+}
+
 // Fake switch plugin.
 type fakeSwitch struct {
 	update       chan *device.StateUpdateData
+	events       chan *device.EventData
 	loadCalled   bool
 	unloadCalled bool
 	onCalled     bool
@@ -128,6 +140,7 @@ type fakeSwitch struct {
 
 func (f *fakeSwitch) Init(data *device.InitDataDevice) error {
 	f.update = data.DeviceStateUpdateChan
+	f.events = data.DeviceEventChan
 	return nil
 }
 
@@ -170,7 +183,14 @@ func (*fakeSwitch) Update() (*device.SwitchState, error) {
 
 func (f *fakeSwitch) Push() {
 	f.update <- &device.StateUpdateData{
-		State: &device.SwitchState{On: true},
+		State: &device.SwitchState{On: false},
+	}
+}
+
+func (f *fakeSwitch) Click() {
+	f.events <- &device.EventData{
+		Event: enums.EvtClick,
+		Data:  map[string]interface{}{"button": 1},
 	}
 }
 
@@ -650,6 +670,7 @@ func TestDeviceDiscoveryAndCommands(t *testing.T) {
 func wait(called *bool) bool {
 	wait := time.NewTicker(10 * time.Millisecond)
 	defer wait.Stop()
+	timeout := time.After(3 * time.Second)
 	for {
 		select {
 		case <-wait.C:
@@ -658,7 +679,7 @@ func wait(called *bool) bool {
 			}
 
 			return true
-		case <-time.After(3 * time.Second):
+		case <-timeout:
 			return false
 		}
 	}
@@ -758,3 +779,124 @@ func TestAPILoadError(t *testing.T) {
 	assert.Equal(t, 0, len(state.devices), "third device num")
 	assert.Nil(t, state.failedDevices, "third failed num")
 }
+
+// Tests removal of the device, reported by hub.
+func TestDeviceRemoval(t *testing.T) {
+	var removed *bus.DeviceRemovedMessage
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddSBCallback(func(msg ...interface{}) {
+		if m, ok := msg[0].(*bus.DeviceRemovedMessage); ok {
+			removed = m
+		}
+	})
+
+	state := newWorkerState(settings)
+	h := &fakeHub{}
+	settings.(mocks.IFakeSettings).AddLoader(h)
+
+	state.DevicesAssignmentMessage(&bus.DeviceAssignmentMessage{
+		Devices: []*bus.DeviceAssignment{
+			{
+				Type:   enums.DevHub,
+				Name:   "fake hub",
+				Plugin: "fake device",
+				Config: "hub",
+			},
+		},
+	})
+
+	time.Sleep(1 * time.Second)
+	s := &fakeSwitch{}
+	settings.(mocks.IFakeSettings).AddLoader(s)
+	h.Disco(s)
+	time.Sleep(1 * time.Second)
+	require.Equal(t, 2, len(state.devices), "discovery didn't work")
+
+	h.Remove(&fakeSwitch{})
+	time.Sleep(1 * time.Second)
+	require.NotNil(t, removed, "master was not notified")
+	assert.Equal(t, "fake_hub.switch.fake_switch", removed.DeviceID, "device id")
+	assert.True(t, s.unloadCalled, "switch was not unloaded")
+	assert.False(t, h.unloadCalled, "hub was unloaded")
+	assert.Equal(t, 1, len(state.devices), "switch was not removed")
+}
+
+// Tests that device events are sent to master.
+func TestDeviceEvent(t *testing.T) {
+	var event *bus.DeviceEventMessage
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddSBCallback(func(msg ...interface{}) {
+		if m, ok := msg[0].(*bus.DeviceEventMessage); ok {
+			event = m
+		}
+	})
+
+	state := newWorkerState(settings)
+	h := &fakeHub{}
+	settings.(mocks.IFakeSettings).AddLoader(h)
+
+	state.DevicesAssignmentMessage(&bus.DeviceAssignmentMessage{
+		Devices: []*bus.DeviceAssignment{
+			{
+				Type:   enums.DevHub,
+				Name:   "fake hub",
+				Plugin: "fake device",
+				Config: "hub",
+			},
+		},
+	})
+
+	time.Sleep(1 * time.Second)
+	s := &fakeSwitch{}
+	settings.(mocks.IFakeSettings).AddLoader(s)
+	h.Disco(s)
+	time.Sleep(1 * time.Second)
+	require.Equal(t, 2, len(state.devices), "discovery didn't work")
+
+	s.Click()
+	time.Sleep(1 * time.Second)
+	require.NotNil(t, event, "master was not notified")
+	assert.Equal(t, "fake_hub.switch.fake_switch", event.DeviceID, "device id")
+	assert.Equal(t, enums.EvtClick, event.Event, "event")
+	assert.Equal(t, 1, event.Data["button"], "data")
+}
+
+// Tests that resync publishes device keyframe.
+func TestDeviceResync(t *testing.T) {
+	var update *bus.DeviceUpdateMessage
+	settings := mocks.FakeNewSettings(nil, true, nil, nil)
+	settings.(mocks.IFakeSettings).AddSBCallback(func(msg ...interface{}) {
+		if m, ok := msg[0].(*bus.DeviceUpdateMessage); ok {
+			update = m
+		}
+	})
+
+	state := newWorkerState(settings)
+	h := &fakeHub{}
+	settings.(mocks.IFakeSettings).AddLoader(h)
+
+	state.DevicesAssignmentMessage(&bus.DeviceAssignmentMessage{
+		Devices: []*bus.DeviceAssignment{
+			{
+				Type:   enums.DevHub,
+				Name:   "fake hub",
+				Plugin: "fake device",
+				Config: "hub",
+			},
+		},
+	})
+
+	time.Sleep(1 * time.Second)
+	s := &fakeSwitch{}
+	settings.(mocks.IFakeSettings).AddLoader(s)
+	h.Disco(s)
+	time.Sleep(1 * time.Second)
+	require.Equal(t, 2, len(state.devices), "discovery didn't work")
+
+	update = nil
+	state.DeviceResyncMessage(bus.NewDeviceResyncMessage("fake_hub.switch.fake_switch"))
+	time.Sleep(500 * time.Millisecond)
+	require.NotNil(t, update, "master was not notified")
+	assert.Equal(t, "fake_hub.switch.fake_switch", update.DeviceID, "device id")
+	assert.False(t, update.IsDelta, "keyframe")
+}
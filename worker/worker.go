@@ -25,6 +25,7 @@ type GoHomeWorker struct {
 	MessageParser bus.IWorkerMessageParserProvider
 
 	workerChan chan busPlugin.RawMessage
+	busPool    bus.IProcessingPoolProvider
 
 	state IWorkerStateProvider
 }
@@ -43,6 +44,12 @@ func NewWorker(settings providers.ISettingsProvider) (*GoHomeWorker, error) {
 		state: newWorkerState(settings),
 	}
 
+	worker.busPool = bus.NewProcessingPool(&bus.ConstructProcessingPool{
+		Logger:    settings.SystemLogger(),
+		Settings:  &settings.WorkerSettings().BusQueue,
+		Processor: worker.MessageParser.ProcessIncomingMessage,
+	})
+
 	return &worker, nil
 }
 
@@ -70,27 +77,32 @@ func (w *GoHomeWorker) busStart() {
 	}
 
 	w.Logger.Debug("Successfully subscribed to worker channels", common.LogSystemToken, logSystem)
+	go w.busPool.Listen(w.workerChan)
 }
 
 // Sending discovery message to the go-home server.
 func (w *GoHomeWorker) sendDiscovery(isFirstStart bool) {
 	w.Logger.Debug("Sending discovery message", common.LogSystemToken, logSystem)
-	w.Settings.ServiceBus().Publish(busPlugin.ChDiscovery, bus.NewDiscoveryMessage(w.Settings.NodeID(), isFirstStart,
-		w.Settings.WorkerSettings().Properties, w.Settings.WorkerSettings().MaxDevices))
+	msg := bus.NewDiscoveryMessage(w.Settings.NodeID(), isFirstStart,
+		w.Settings.WorkerSettings().Properties, w.Settings.WorkerSettings().MaxDevices)
+	msg.BusQueue = w.busPool.Stats()
+	w.Settings.ServiceBus().Publish(busPlugin.ChDiscovery, msg)
 }
 
 // Processing incoming service-bus messages.
+// Messages are dispatched in the order they were parsed, device commands are
+// processed by the per-device queue and resync only enqueues keyframe, so slow devices don't block the cycle.
 func (w *GoHomeWorker) busCycle() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	for {
 		select {
-		case msg := <-w.workerChan:
-			go w.MessageParser.ProcessIncomingMessage(&msg)
 		case assign := <-w.MessageParser.GetDeviceAssignmentMessageChan():
 			w.state.DevicesAssignmentMessage(assign)
 		case cmd := <-w.MessageParser.GetDeviceCommandMessageChan():
-			go w.state.DevicesCommandMessage(cmd)
+			w.state.DevicesCommandMessage(cmd)
+		case resync := <-w.MessageParser.GetDeviceResyncMessageChan():
+			w.state.DeviceResyncMessage(resync)
 		case <-c:
 			w.Logger.Info("Received stop command, exiting", common.LogSystemToken, logSystem)
 			os.Exit(0)